@@ -0,0 +1,179 @@
+package errs
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// With adds the attribute key with value to the error. Values are stored like slog.Any stores them.
+func (b *Builder) With(key string, value any) *Builder {
+	b.err.Attrs = append(b.err.Attrs, slog.Any(key, value))
+	return b
+}
+
+// WithAttrs adds attributes to the error.
+func (b *Builder) WithAttrs(attrs ...slog.Attr) *Builder {
+	b.err.Attrs = append(b.err.Attrs, attrs...)
+	return b
+}
+
+// Attrs returns the attributes of all errors in the tree of err, outermost first. The tree is walked in the same
+// order as CodeOf, including errors joined with errors.Join and contained in a *MultiError.
+//
+// When several errors have attributes with the same key, only the attribute of the first error is returned,
+// so outer errors override the attributes of the errors they wrap.
+func Attrs(err error) []slog.Attr {
+	var result []slog.Attr
+	collectAttrs(err, &result)
+	return result
+}
+
+// AttrValue returns the value of the attribute with the key returned by Attrs for err.
+func AttrValue(err error, key string) (slog.Value, bool) {
+	for _, a := range Attrs(err) {
+		if a.Key == key {
+			return a.Value.Resolve(), true
+		}
+	}
+	return slog.Value{}, false
+}
+
+// Attr returns the value of the attribute with the key returned by Attrs for err, if it is a T.
+//
+// slog stores integers as int64 or uint64 and floats as float64, so numbers are converted to T when T is a number type
+// and the conversion keeps the value. For example, a value added with With("user_id", 42) is returned by both
+// Attr[int] and Attr[int8], but not by Attr[int8] for 300 or Attr[int] for 3.7. Floats without fractional part,
+// such as numbers decoded from JSON, are converted to integer types, integers are never converted to floats.
+func Attr[T any](err error, key string) (T, bool) {
+	var zero T
+	v, ok := AttrValue(err, key)
+	if !ok {
+		return zero, false
+	}
+	if t, ok := v.Any().(T); ok {
+		return t, true
+	}
+
+	from, to := reflect.ValueOf(v.Any()), reflect.TypeOf(zero)
+	if to == nil || !from.IsValid() || !isNumber(from.Kind()) || !isNumber(to.Kind()) {
+		return zero, false
+	}
+	converted, ok := convertNumber(from, to)
+	if !ok {
+		return zero, false
+	}
+	return converted.Interface().(T), true
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// convertNumber converts the number v to the number type to. ok is false when the conversion would change the value.
+func convertNumber(v reflect.Value, to reflect.Type) (_ reflect.Value, ok bool) {
+	if isFloat(v.Kind()) {
+		f := v.Float()
+		if isFloat(to.Kind()) {
+			c := v.Convert(to)
+			return c, c.Float() == f
+		}
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= 1<<64 {
+			return reflect.Value{}, false
+		}
+		// floats without fractional part are converted like integers
+		if f < 0 {
+			v = reflect.ValueOf(int64(f))
+		} else {
+			v = reflect.ValueOf(uint64(f))
+		}
+	}
+	if isFloat(to.Kind()) {
+		return reflect.Value{}, false
+	}
+
+	target := reflect.New(to).Elem()
+	if v.CanInt() {
+		n := v.Int()
+		if target.CanInt() {
+			ok = !target.OverflowInt(n)
+		} else {
+			ok = n >= 0 && !target.OverflowUint(uint64(n))
+		}
+	} else {
+		n := v.Uint()
+		if target.CanUint() {
+			ok = !target.OverflowUint(n)
+		} else {
+			ok = n <= math.MaxInt64 && !target.OverflowInt(int64(n))
+		}
+	}
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return v.Convert(to), true
+}
+
+// collectAttrs implements Attrs, attributes with keys already in result are skipped.
+func collectAttrs(err error, result *[]slog.Attr) {
+	walk(err, func(err error) bool {
+		if x, ok := err.(*Error); ok {
+			for _, a := range x.Attrs {
+				if !slices.ContainsFunc(*result, func(r slog.Attr) bool { return r.Key == a.Key }) {
+					*result = append(*result, a)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// attrValue returns the value of an attribute for encoding it to JSON.
+// Groups are returned as maps and errors as their message.
+func attrValue(v slog.Value) any {
+	v = v.Resolve()
+	switch v.Kind() {
+	case slog.KindGroup:
+		return attrMap(v.Group())
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+	}
+	return v.Any()
+}
+
+func attrMap(attrs []slog.Attr) map[string]any {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(attrs))
+	for _, a := range attrs {
+		m[a.Key] = attrValue(a.Value)
+	}
+	return m
+}
+
+// attrsGoString returns the Go syntax representation of attributes.
+func attrsGoString(attrs []slog.Attr) string {
+	return "[]slog.Attr{" + attrsArgs(attrs) + "}"
+}
+
+func attrsArgs(attrs []slog.Attr) string {
+	s := make([]string, 0, len(attrs))
+	for _, a := range attrs {
+		if a.Value.Kind() == slog.KindGroup {
+			s = append(s, fmt.Sprintf("slog.Group(%q, %s)", a.Key, attrsArgs(a.Value.Group())))
+			continue
+		}
+		s = append(s, fmt.Sprintf("slog.Any(%q, %#v)", a.Key, a.Value.Any()))
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,170 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttrs(t *testing.T) {
+	inner := B().Code(NotFound).With("user_id", 42).With("table", "users").Err()
+	outer := WrapB(inner).With("user_id", 7).WithAttrs(slog.String("request_id", "abc")).Err()
+
+	testcases := []struct {
+		name   string
+		err    error
+		expect []slog.Attr
+	}{
+		{"nil", nil, nil},
+		{"foreign error", io.EOF, nil},
+		{"single error", inner, []slog.Attr{slog.Int("user_id", 42), slog.String("table", "users")}},
+		{
+			name:   "outer error overrides inner error",
+			err:    outer,
+			expect: []slog.Attr{slog.Int("user_id", 7), slog.String("request_id", "abc"), slog.String("table", "users")},
+		},
+		{"wrapped by fmt.Errorf", fmt.Errorf("handler: %w", inner), []slog.Attr{slog.Int("user_id", 42), slog.String("table", "users")}},
+		{
+			name: "joined errors",
+			err:  errors.Join(B().With("order_id", "o-1").Err(), inner),
+			expect: []slog.Attr{
+				slog.String("order_id", "o-1"), slog.Int("user_id", 42), slog.String("table", "users"),
+			},
+		},
+		{
+			name:   "multi error",
+			err:    MultiB().Add(B().With("user_id", 1).Err(), inner).ErrOrNil(),
+			expect: []slog.Attr{slog.Int("user_id", 1), slog.String("table", "users")},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Attrs(tc.err)
+			require.Len(t, got, len(tc.expect))
+			for i := range got {
+				assert.True(t, tc.expect[i].Equal(got[i]), "expected %v, got %v", tc.expect[i], got[i])
+			}
+		})
+	}
+}
+
+func TestAttr(t *testing.T) {
+	err := fmt.Errorf("handler: %w", B().
+		With("user_id", 42).
+		With("name", "john").
+		With("timeout", time.Second).
+		With("ratio", float32(0.5)).
+		With("cause", io.EOF).
+		Err())
+
+	id, ok := Attr[int](err, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, 42, id)
+
+	id64, ok := Attr[int64](err, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), id64)
+
+	name, ok := Attr[string](err, "name")
+	assert.True(t, ok)
+	assert.Equal(t, "john", name)
+
+	timeout, ok := Attr[time.Duration](err, "timeout")
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, timeout)
+
+	ratio, ok := Attr[float32](err, "ratio")
+	assert.True(t, ok)
+	assert.Equal(t, float32(0.5), ratio)
+
+	cause, ok := Attr[error](err, "cause")
+	assert.True(t, ok)
+	assert.Equal(t, io.EOF, cause)
+
+	_, ok = Attr[string](err, "user_id")
+	assert.False(t, ok, "numbers are not converted to strings")
+
+	id8, ok := Attr[int8](err, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, int8(42), id8)
+
+	_, ok = Attr[int](err, "missing")
+	assert.False(t, ok)
+
+	v, ok := AttrValue(err, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, slog.KindInt64, v.Kind())
+}
+
+func TestBuilder_Details_Appends(t *testing.T) {
+	err := B().Details("first").Details("second", 3).Err().(*Error)
+	assert.Equal(t, []any{"first", "second", 3}, err.Details)
+}
+
+func TestAttrs_Encoding(t *testing.T) {
+	err := B().Code(NotFound).Msg("user not found").
+		With("user_id", 42).
+		WithAttrs(slog.Group("request", slog.String("method", "GET"))).
+		Err().(*Error)
+
+	assert.Equal(t, "not_found: user not found\n\tuser_id=42\n\trequest=[method=GET]\n\n", err.Stack())
+	assert.Equal(t,
+		`&errs.Error{Code:errs.NotFound, Msg:[]string{"user not found"}, Attrs:[]slog.Attr{slog.Any("user_id", 42), slog.Group("request", slog.Any("method", "GET"))}}`,
+		err.GoString(),
+	)
+
+	b, jsonErr := json.Marshal(err)
+	require.NoError(t, jsonErr)
+	assert.NotContains(t, string(b), "user_id", "attributes are not sent to clients")
+
+	b, jsonErr = json.Marshal(Debug(err))
+	require.NoError(t, jsonErr)
+	assert.Contains(t, string(b), `"attrs":{"request":{"method":"GET"},"user_id":42}`)
+
+	var decoded Error
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	id, ok := Attr[int](&decoded, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, 42, id)
+}
+
+func TestAttr_conversions(t *testing.T) {
+	err := B().
+		With("large", 300).
+		With("negative", -1).
+		With("fraction", 3.7).
+		With("integral", 42.0).
+		With("precise", 0.1).
+		With("unsigned", uint64(1<<63)).
+		Err()
+
+	_, ok := Attr[int8](err, "large")
+	assert.False(t, ok, "overflow")
+	_, ok = Attr[uint](err, "negative")
+	assert.False(t, ok, "negative to unsigned")
+	_, ok = Attr[int](err, "fraction")
+	assert.False(t, ok, "fractional part")
+	_, ok = Attr[float32](err, "precise")
+	assert.False(t, ok, "precision loss")
+	_, ok = Attr[int64](err, "unsigned")
+	assert.False(t, ok, "overflow of unsigned")
+	_, ok = Attr[float64](err, "large")
+	assert.False(t, ok, "integers are not converted to floats")
+
+	n, ok := Attr[int](err, "integral")
+	assert.True(t, ok)
+	assert.Equal(t, 42, n)
+	u, ok := Attr[uint16](err, "large")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(300), u)
+	neg, ok := Attr[int8](err, "negative")
+	assert.True(t, ok)
+	assert.Equal(t, int8(-1), neg)
+}
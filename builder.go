@@ -1,7 +1,6 @@
 package errs
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 )
@@ -13,31 +12,36 @@ import (
 //
 // 1. B() -> new error
 //
-// 2. B(err) -> update existing error (because of pointers) and returns it after calling Err()
+// 2. B(err) -> new error that is a copy of err, err itself is never modified.
+// Errors that are not *Error are converted with Convert.
+// The new error still matches err with errors.Is, which makes it safe to add context to shared errors:
 //
-// 3. B(err1, err2, err3) -> updates only err1 and returns it after calling Err()
+//	var ErrNotFound = errs.B().Code(errs.NotFound).Msg("not found").Err()
+//
+//	err := errs.B(ErrNotFound).Msg("user 42").Err() // errors.Is(err, ErrNotFound) == true
+//
+// 3. B(err1, err2, err3) -> same as B(err1)
 func B(initial ...error) *Builder {
-	var err *Error
 	if len(initial) <= 0 || initial[0] == nil {
-		err = new(Error)
-	} else {
-		err = convert(initial[0])
+		return &Builder{err: new(Error)}
+	}
+	if err, ok := initial[0].(*Error); ok {
+		return &Builder{err: err.derive()}
 	}
-	return &Builder{err}
+	return &Builder{err: convert(initial[0])}
 }
 
 // WrapB wraps an underlying error and returns a builder for the new error.
 func WrapB(err error) *Builder {
-	var cause *Error
-	errors.As(err, &cause)
 	b := B(nil)
-	b.err.wrap(cause)
+	b.err.wrap(convert(err))
 	return b
 }
 
 // Builder is used to build an instance of `Error` object.
 type Builder struct {
-	err *Error
+	err   *Error
+	trace bool
 }
 
 // Code sets the code of the error.
@@ -74,9 +78,15 @@ func (b *Builder) Msgf(format string, parameters ...any) *Builder {
 	return b
 }
 
-// Details adds details to the error.
+// InternalMsg adds messages for developers to the error. Unlike Msg, they are never sent to clients, see Error.SafeError.
+func (b *Builder) InternalMsg(msg ...string) *Builder {
+	b.err.InternalMsg = append(b.err.InternalMsg, cleanStrings(msg)...)
+	return b
+}
+
+// Details adds details to the error. Use With to add values that can be looked up by key.
 func (b *Builder) Details(details ...any) *Builder {
-	b.err.Details = details
+	b.err.Details = append(b.err.Details, details...)
 	return b
 }
 
@@ -93,7 +103,14 @@ func (b *Builder) Show() *Builder {
 	return b
 }
 
+// Trace captures the stack trace of the error when Err is called, even if tracing is disabled with SetTracing.
+func (b *Builder) Trace() *Builder {
+	b.trace = true
+	return b
+}
+
 // Err returns new instance of `Error`.
 func (b *Builder) Err() error {
+	b.err.capture(1, b.trace)
 	return b.err
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -59,10 +60,39 @@ func TestWrapB(t *testing.T) {
 }
 
 func TestBuilder(t *testing.T) {
-	t.Run("should update existing error", func(t *testing.T) {
-		err := B().Code(NotFound).Msg("item not found").Err() // err is updated by pointer
-		updated := B(err).Code(InvalidArgument).Msg("invalid argument").Err()
-		assert.Equal(t, err, updated)
+	t.Run("should not update existing error", func(t *testing.T) {
+		err := B().Code(NotFound).Msg("item not found").Details("id").Err()
+		updated := B(err).Code(InvalidArgument).Msg("invalid argument").Details("extra").Op("Items.Get").Err()
+
+		assert.Equal(t, &Error{Code: NotFound, Msg: []string{"item not found"}, Details: []any{"id"}}, err)
+		assert.Equal(t, "invalid_argument: Items.Get: item not found: invalid argument", updated.Error())
+		assert.ErrorIs(t, updated, err)
+		assert.False(t, errors.Is(err, updated))
+	})
+	t.Run("derived errors match all their origins", func(t *testing.T) {
+		sentinel := B().Code(NotFound).Msg("not found").Err()
+		user := B(sentinel).Msg("user").Err()
+		derived := B(user).Msg("42").Err()
+
+		assert.Equal(t, "not_found: not found: user: 42", derived.Error())
+		assert.ErrorIs(t, derived, sentinel)
+		assert.ErrorIs(t, derived, user)
+		assert.ErrorIs(t, WrapMsg(derived, "fetching user"), sentinel)
+		assert.Equal(t, "not_found: not found", sentinel.Error())
+	})
+	t.Run("concurrent use of a shared error", func(t *testing.T) {
+		sentinel := B().Code(NotFound).Msg("not found").Err()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := B(sentinel).Msgf("item %d", i).Err()
+				assert.Equal(t, fmt.Sprintf("not_found: not found: item %d", i), err.Error())
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, "not_found: not found", sentinel.Error())
 	})
 	t.Run("passed nil error", func(t *testing.T) {
 		err := B(nil).Code(NotFound).Msg("item not found").Err()
@@ -75,7 +105,9 @@ func TestBuilder(t *testing.T) {
 		assert.Equal(
 			t,
 			&Error{Code: NotFound,
-				Msg: []string{"not our error", "item not found"},
+				Msg:         []string{"item not found"},
+				InternalMsg: []string{"not our error"},
+				foreign:     err,
 			},
 			updated,
 		)
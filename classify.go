@@ -0,0 +1,142 @@
+package errs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Classifier returns the code of an error that is not an *Error.
+// ok is false when the classifier does not know the error.
+type Classifier func(err error) (code Code, ok bool)
+
+var (
+	// classifiersMu serializes registrations of classifiers
+	classifiersMu sync.Mutex
+
+	// classifiers contains the registered classifiers, latest first. The slice is never modified once stored.
+	// Pointers identify registrations, as functions cannot be compared.
+	classifiers atomic.Pointer[[]*Classifier]
+)
+
+// RegisterClassifier registers a Classifier used to find the code of errors that are not *Error when they are
+// converted with Convert, and so by WrapMsg, WrapCode, Wrap and B. CodeOf also uses them when no code is found
+// in the tree of an error.
+//
+// Classifiers are tried from the latest registered to the first, and before the built-in classifiers
+// which handle errors of the context, os, net and database/sql packages as well as gRPC status errors.
+// It is safe to call RegisterClassifier concurrently with conversions.
+//
+// The returned function unregisters the classifier, e.g. at the end of a test. Calling it more than once is a no-op.
+func RegisterClassifier(c Classifier) (unregister func()) {
+	registration := &c
+	updateClassifiers(func(registered []*Classifier) []*Classifier {
+		return slices.Insert(registered, 0, registration)
+	})
+	return func() {
+		updateClassifiers(func(registered []*Classifier) []*Classifier {
+			return slices.DeleteFunc(registered, func(r *Classifier) bool { return r == registration })
+		})
+	}
+}
+
+// updateClassifiers applies fn to a copy of the registered classifiers and stores the result.
+func updateClassifiers(fn func(registered []*Classifier) []*Classifier) {
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+
+	var registered []*Classifier
+	if old := classifiers.Load(); old != nil {
+		registered = slices.Clone(*old)
+	}
+	registered = fn(registered)
+	classifiers.Store(&registered)
+}
+
+// builtinClassifiers are used after the registered classifiers.
+var builtinClassifiers = []Classifier{
+	classifyContext,
+	classifyOS,
+	classifyNet,
+	classifySQL,
+	classifyGRPC,
+}
+
+// classify returns the code of err found by the classifiers, or Unknown.
+func classify(err error) Code {
+	if registered := classifiers.Load(); registered != nil {
+		for _, classifier := range *registered {
+			if c, ok := (*classifier)(err); ok {
+				return c
+			}
+		}
+	}
+	for _, classifier := range builtinClassifiers {
+		if c, ok := classifier(err); ok {
+			return c
+		}
+	}
+	return Unknown
+}
+
+func classifyContext(err error) (Code, bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Canceled, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded, true
+	}
+	return Unknown, false
+}
+
+func classifyOS(err error) (Code, bool) {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return NotFound, true
+	case errors.Is(err, os.ErrPermission):
+		return Forbidden, true
+	case errors.Is(err, os.ErrExist):
+		return AlreadyExists, true
+	}
+	return Unknown, false
+}
+
+func classifyNet(err error) (Code, bool) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return DeadlineExceeded, true
+	}
+	return Unknown, false
+}
+
+func classifySQL(err error) (Code, bool) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return NotFound, true
+	case errors.Is(err, sql.ErrTxDone):
+		return FailedPrecondition, true
+	}
+	return Unknown, false
+}
+
+// classifyGRPC returns the default code mapped to the gRPC code of status errors.
+func classifyGRPC(err error) (Code, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.OK {
+		return Unknown, false
+	}
+	for c := Code(0); c < CodeSize; c++ {
+		if grpcCodes[c] == st.Code() {
+			return c, true
+		}
+	}
+	return Unknown, false
+}
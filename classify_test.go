@@ -0,0 +1,87 @@
+package errs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConvert_classifiers(t *testing.T) {
+	testcases := []struct {
+		name   string
+		err    error
+		expect Code
+	}{
+		{"unknown error", io.EOF, Unknown},
+		{"context canceled", context.Canceled, Canceled},
+		{"context deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), DeadlineExceeded},
+		{"file not found", &fs.PathError{Op: "open", Path: "/tmp/x", Err: fs.ErrNotExist}, NotFound},
+		{"permission denied", fmt.Errorf("open: %w", os.ErrPermission), Forbidden},
+		{"file exists", os.ErrExist, AlreadyExists},
+		{"net timeout", &net.DNSError{Err: "timeout", IsTimeout: true}, DeadlineExceeded},
+		{"net error without timeout", &net.DNSError{Err: "no such host", IsNotFound: true}, Unknown},
+		{"io deadline", os.ErrDeadlineExceeded, DeadlineExceeded},
+		{"no rows", fmt.Errorf("get user: %w", sql.ErrNoRows), NotFound},
+		{"tx done", sql.ErrTxDone, FailedPrecondition},
+		{"grpc status", status.Error(codes.PermissionDenied, "denied"), Forbidden},
+		{"grpc status without mapping", status.Error(codes.Unimplemented, "not implemented"), Unknown},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, Convert(tc.err).(*Error).Code)
+			assert.Equal(t, tc.expect, WrapMsg(tc.err, "wrapped").(*Error).Code)
+			assert.Equal(t, tc.expect, WrapCode(tc.err, Unknown, "wrapped").(*Error).Code)
+			assert.Equal(t, tc.expect, CodeOf(tc.err))
+			assert.ErrorIs(t, Convert(tc.err), tc.err)
+		})
+	}
+
+	t.Run("explicit code wins", func(t *testing.T) {
+		assert.Equal(t, Internal, WrapCode(sql.ErrNoRows, Internal, "query failed").(*Error).Code)
+	})
+}
+
+type driverError struct {
+	code string
+}
+
+func (e *driverError) Error() string { return "driver error " + e.code }
+
+func TestRegisterClassifier(t *testing.T) {
+	unregister := RegisterClassifier(func(err error) (Code, bool) {
+		var dErr *driverError
+		if errors.As(err, &dErr) && dErr.code == "23505" {
+			return AlreadyExists, true
+		}
+		return Unknown, false
+	})
+	defer unregister()
+	unregisterAborted := RegisterClassifier(func(err error) (Code, bool) {
+		var dErr *driverError
+		if errors.As(err, &dErr) && dErr.code == "57014" {
+			// takes precedence over the built-in context classifier
+			return Aborted, true
+		}
+		return Unknown, false
+	})
+
+	assert.Equal(t, AlreadyExists, Convert(&driverError{code: "23505"}).(*Error).Code)
+	assert.Equal(t, Unknown, Convert(&driverError{code: "42P01"}).(*Error).Code)
+	assert.Equal(t, Aborted, CodeOf(errors.Join(&driverError{code: "57014"}, context.Canceled)))
+	assert.Equal(t, AlreadyExists, CodeOf(fmt.Errorf("insert: %w", &driverError{code: "23505"})))
+
+	unregisterAborted()
+	unregisterAborted()
+	assert.Equal(t, Canceled, CodeOf(errors.Join(&driverError{code: "57014"}, context.Canceled)))
+	assert.Equal(t, AlreadyExists, CodeOf(&driverError{code: "23505"}), "other classifiers are kept")
+}
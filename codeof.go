@@ -0,0 +1,99 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+)
+
+// CodeOf returns the first known code found in the tree of err, walking it in the same order as errors.Is:
+// wrapped errors, including errors joined with errors.Join, are visited depth first.
+//
+// - *Error contributes its code if it is not Unknown.
+//
+// - *MultiError contributes its derived code.
+//
+// When no known code is found, the code returned by the classifiers for err is used, see RegisterClassifier.
+// Unknown is returned if the classifiers do not know err either, or err is nil.
+func CodeOf(err error) Code {
+	if err == nil {
+		return Unknown
+	}
+	if c, ok := codeOf(err); ok {
+		return c
+	}
+	return classify(err)
+}
+
+// IsCode reports whether the code returned by CodeOf for err is one of codes.
+func IsCode(err error, codes ...Code) bool {
+	return slices.Contains(codes, CodeOf(err))
+}
+
+// HTTPStatusOf returns the HTTP code mapped to the code returned by CodeOf for err.
+// http.StatusOK is returned if err is nil.
+func HTTPStatusOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	return CodeOf(err).HTTP()
+}
+
+// GRPCCodeOf returns the gRPC code mapped to the code returned by CodeOf for err.
+// codes.OK is returned if err is nil.
+func GRPCCodeOf(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	return CodeOf(err).GRPC()
+}
+
+// codeOf implements CodeOf, ok is false if no known code was found.
+func codeOf(err error) (c Code, ok bool) {
+	for err != nil {
+		switch x := err.(type) {
+		case *Error:
+			if x.Code != Unknown {
+				return x.Code, true
+			}
+		case *MultiError:
+			if c = x.Code(); c != Unknown {
+				return c, true
+			}
+			return Unknown, false
+		}
+
+		if x, isMulti := err.(interface{ Unwrap() []error }); isMulti {
+			for _, inner := range x.Unwrap() {
+				if c, ok = codeOf(inner); ok {
+					return c, true
+				}
+			}
+			return Unknown, false
+		}
+		err = errors.Unwrap(err)
+	}
+	return Unknown, false
+}
+
+// walk calls fn for err and the errors in its tree, depth first in the same order as errors.Is, until fn
+// returns false.
+func walk(err error, fn func(error) bool) bool {
+	for err != nil {
+		if !fn(err) {
+			return false
+		}
+		if x, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, inner := range x.Unwrap() {
+				if !walk(inner, fn) {
+					return false
+				}
+			}
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return true
+}
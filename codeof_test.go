@@ -0,0 +1,63 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCodeOf(t *testing.T) {
+	notFound := B().Code(NotFound).Msg("user not found").Err()
+	testcases := []struct {
+		name   string
+		err    error
+		expect Code
+	}{
+		{"nil", nil, Unknown},
+		{"foreign error", io.EOF, Unknown},
+		{"errs error", notFound, NotFound},
+		{"first known code", WrapCode(notFound, Unknown, "fetching user"), NotFound},
+		{"outer code wins", WrapCode(notFound, Internal, "fetching user"), Internal},
+		{"wrapped by fmt.Errorf", fmt.Errorf("handler: %w", fmt.Errorf("service: %w", notFound)), NotFound},
+		{"joined errors", errors.Join(io.EOF, B().Msg("no code").Err(), fmt.Errorf("x: %w", notFound)), NotFound},
+		{"joined errors without code", errors.Join(io.EOF, B().Msg("no code").Err()), Unknown},
+		{"multi error", fmt.Errorf("x: %w", MultiB().Add(notFound, B().Code(Unavailable).Err()).ErrOrNil()), Unavailable},
+		{"context canceled", fmt.Errorf("query: %w", context.Canceled), Canceled},
+		{"context deadline", WrapMsg(context.DeadlineExceeded, "query"), DeadlineExceeded},
+		{"errs code before context error", WrapCode(context.DeadlineExceeded, Unavailable), Unavailable},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, CodeOf(tc.err))
+		})
+	}
+}
+
+func TestIsCode(t *testing.T) {
+	err := fmt.Errorf("handler: %w", B().Code(NotFound).Err())
+	assert.True(t, IsCode(err, NotFound))
+	assert.True(t, IsCode(err, InvalidArgument, NotFound))
+	assert.False(t, IsCode(err, InvalidArgument))
+	assert.False(t, IsCode(err))
+	assert.True(t, IsCode(io.EOF, Unknown))
+}
+
+func TestHTTPStatusOf(t *testing.T) {
+	assert.Equal(t, http.StatusOK, HTTPStatusOf(nil))
+	assert.Equal(t, http.StatusNotFound, HTTPStatusOf(fmt.Errorf("x: %w", B().Code(NotFound).Err())))
+	assert.Equal(t, http.StatusGatewayTimeout, HTTPStatusOf(context.DeadlineExceeded))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatusOf(io.EOF))
+}
+
+func TestGRPCCodeOf(t *testing.T) {
+	assert.Equal(t, codes.OK, GRPCCodeOf(nil))
+	assert.Equal(t, codes.NotFound, GRPCCodeOf(fmt.Errorf("x: %w", B().Code(NotFound).Err())))
+	assert.Equal(t, codes.Canceled, GRPCCodeOf(context.Canceled))
+	assert.Equal(t, codes.Unknown, GRPCCodeOf(io.EOF))
+}
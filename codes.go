@@ -1,15 +1,14 @@
 package errs
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"google.golang.org/grpc/codes"
 )
 
-func init() {
-	initMaps()
-}
-
 // Code is the type that represents an error code.
 // It can map to HTTP and gRPC codes.
 // In order to properly work with custom codes or code overrides:
@@ -75,72 +74,123 @@ const (
 //	)
 const CodeSize = 15
 
-// String returns the string representation of the code.
+// String returns the string representation of the code, which is its stable name, e.g. "not_found".
+// Use Description for the human description of the code. Codes that are neither built-in nor registered
+// are named after their value, e.g. "code(18)".
 func (c Code) String() string {
-	if x, ok := cDesc[c]; ok {
-		return x
+	return defaultRegistry.String(c)
+}
+
+// GoString implements the fmt.GoStringer interface and returns the Go syntax representation of the code,
+// e.g. errs.NotFound for default codes and errs.Code(15) for others.
+func (c Code) GoString() string {
+	if !c.builtin() {
+		return "errs.Code(" + strconv.Itoa(int(c)) + ")"
+	}
+	var buf strings.Builder
+	buf.WriteString("errs.")
+	for _, word := range strings.Split(codeNames[c], "_") {
+		buf.WriteString(strings.ToUpper(word[:1]) + word[1:])
 	}
-	return codeNames[c]
+	return buf.String()
 }
 
 // MarshalJSON implements the json.Marshaler interface and defines how a Code
-// should be marshaled to JSON. By default, it marshals to a string representation defined by String function.
+// should be marshaled to JSON. It marshals to the name of the code returned by String.
 func (c Code) MarshalJSON() ([]byte, error) {
 	s := c.String()
 	return []byte("\"" + s + "\""), nil
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface and decodes a Code from the string
+// representation produced by MarshalJSON, see ParseCode. Names that are not known are decoded as Unknown.
+func (c *Code) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*c, _ = defaultRegistry.ParseCode(s)
+	return nil
+}
+
+// ParseCode returns the code whose name is s, see Code.String. Names of registered codes take precedence over
+// the default names. Descriptions are accepted too, since earlier versions used them as string representation
+// of registered codes. An error is returned when no code has the name or description s.
+func ParseCode(s string) (Code, error) {
+	return defaultRegistry.ParseCode(s)
+}
+
 // HTTP returns the HTTP code that is mapped to the code.
+// Codes that are neither built-in nor registered are mapped like Unknown.
 func (c Code) HTTP() int {
-	if x, ok := cHttp[c]; ok {
-		return x
-	}
-	return httpCodes[c]
+	return defaultRegistry.HTTP(c)
 }
 
 // GRPC returns the gPRC code that is mapped to the code.
+// Codes that are neither built-in nor registered are mapped like Unknown.
 func (c Code) GRPC() codes.Code {
-	if x, ok := cGrpc[c]; ok {
-		return x
-	}
-	return grpcCodes[c]
+	return defaultRegistry.GRPC(c)
 }
 
-// RegisterCode registers a new code OR overrides an existing one.
-func RegisterCode(c Code, HTTP int, GRPC codes.Code, desc string) {
-	cHttp[c] = HTTP
-	cGrpc[c] = GRPC
-	cDesc[c] = desc
+// RegisterCode registers a new code OR overrides an existing one. It is a shorthand for RegisterCodeSpec
+// with the description, HTTP and gRPC codes. New codes should be given a stable name with WithName,
+// otherwise their name is derived from the description.
+// It is safe to call RegisterCode concurrently with other registrations and lookups.
+func RegisterCode(c Code, HTTP int, GRPC codes.Code, desc string, opts ...CodeOption) {
+	defaultRegistry.Register(c, HTTP, GRPC, desc, opts...)
 }
 
 // UnregisterCode unregisters the custom implementation or override of a code
 // provided from the RegisterCode function.
 // When a code is unregistered, UnregisterCode is a no-op.
 func UnregisterCode(c Code) {
-	delete(cHttp, c)
-	delete(cGrpc, c)
-	delete(cDesc, c)
+	defaultRegistry.Unregister(c)
 }
 
 // IsRegistered returns true if a custom implementation or override is being used for the code.
 func IsRegistered(c Code) bool {
-	_, ok := cHttp[c]
-	return ok
+	return defaultRegistry.IsRegistered(c)
+}
+
+// Codes returns the built-in codes followed by the registered codes that are not built-in, in ascending order.
+func Codes() []Code {
+	return defaultRegistry.Codes()
+}
+
+// ValidateCodes checks the registrations of the codes cs in strict mode and reports the codes that are neither
+// built-in nor registered, or whose name is derived from their description, see Registry.Validate. Call it once the codes are registered, e.g. in a test:
+//
+//	func TestCodes(t *testing.T) {
+//		if err := errs.ValidateCodes(MyCode, ExtraCode); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+func ValidateCodes(cs ...Code) error {
+	return defaultRegistry.Validate(cs...)
 }
 
 // ClearCodeRegister removes all registration made
 // with the function RegisterCode
 func ClearCodeRegister() {
-	for k := range cHttp {
-		UnregisterCode(k)
+	defaultRegistry.Clear()
+}
+
+// builtin reports whether c is one of the codes defined by this package.
+func (c Code) builtin() bool {
+	return c >= 0 && c < CodeSize
+}
+
+// fallback returns c if it is built-in and Unknown otherwise, for indexing the default mappings.
+func (c Code) fallback() Code {
+	if c.builtin() {
+		return c
 	}
-	initMaps()
+	return Unknown
 }
 
-func initMaps() {
-	cHttp = make(map[Code]int)
-	cGrpc = make(map[Code]codes.Code)
-	cDesc = make(map[Code]string)
+// valueName returns the name of codes that are neither built-in nor registered, e.g. "code(18)".
+func valueName(c Code) string {
+	return "code(" + strconv.Itoa(int(c)) + ")"
 }
 
 // httpCodes is an array that contains DEFAULT mappings for
@@ -201,12 +251,3 @@ var grpcCodes = [...]codes.Code{
 	OutOfRange:         codes.OutOfRange,
 	Unavailable:        codes.Unavailable,
 }
-
-var (
-	// cHttp is a map that contains custom mappings for codes to http codes
-	cHttp map[Code]int
-	// cGrpc is a map that contains custom mappings for codes to grpc codes
-	cGrpc map[Code]codes.Code
-	// cDesc is a map that contains custom mappings for codes to their descriptions
-	cDesc map[Code]string
-)
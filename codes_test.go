@@ -3,6 +3,7 @@ package errs
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"testing"
 )
@@ -77,7 +78,7 @@ func TestRegisterCode(t *testing.T) {
 			RegisterCode(tt.args.c, tt.args.http, tt.args.grpc, tt.args.desc)
 			assert.Equal(t, tt.args.http, tt.args.c.HTTP())
 			assert.Equal(t, tt.args.grpc, tt.args.c.GRPC())
-			assert.Equal(t, tt.args.desc, tt.args.c.String())
+			assert.Equal(t, tt.args.desc, tt.args.c.Description())
 
 			// test register check
 			assert.True(t, IsRegistered(tt.args.c))
@@ -105,3 +106,39 @@ func TestRegisterCode(t *testing.T) {
 		}
 	})
 }
+
+func TestParseCode(t *testing.T) {
+	for i := 0; i < CodeSize; i++ {
+		c, err := ParseCode(Code(i).String())
+		require.NoError(t, err)
+		assert.Equal(t, Code(i), c)
+	}
+
+	custom := Code(CodeSize + 11)
+	RegisterCode(custom, 402, codes.FailedPrecondition, "Payment declined!")
+	defer UnregisterCode(custom)
+	assert.Equal(t, "payment_declined", custom.String(), "derived from the description")
+	assert.Equal(t, "Payment declined!", custom.Description())
+	assert.EqualError(t, ValidateCodes(custom), `errs: the name "payment_declined" of code(26) is derived from its description`)
+
+	for _, s := range []string{"payment_declined", "Payment declined!"} {
+		c, err := ParseCode(s)
+		require.NoError(t, err)
+		assert.Equal(t, custom, c, s)
+	}
+
+	RegisterCode(custom, 402, codes.FailedPrecondition, "The payment was declined", WithName("payment_declined"))
+	assert.Equal(t, "payment_declined", custom.String())
+	assert.NoError(t, ValidateCodes(custom))
+
+	_, err := ParseCode("no_such_code")
+	assert.EqualError(t, err, `errs: unknown code "no_such_code"`)
+}
+
+func TestCode_GoString(t *testing.T) {
+	assert.Equal(t, "errs.Unknown", Unknown.GoString())
+	assert.Equal(t, "errs.NotFound", NotFound.GoString())
+	assert.Equal(t, "errs.DeadlineExceeded", DeadlineExceeded.GoString())
+	assert.Equal(t, "errs.Code(15)", Code(CodeSize).GoString())
+	assert.Equal(t, "errs.Code(-1)", Code(-1).GoString())
+}
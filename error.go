@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"io"
 	"iter"
+	"log/slog"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Separator is the default separator between elements of a single error
@@ -15,24 +19,49 @@ type Error struct {
 	// underlying error
 	cause *Error
 
+	// foreign is the original error this node was converted from when it is not an *Error.
+	// It is kept so that errors.Is and errors.As can still match it through the chain.
+	foreign error
+
 	// Op operation where error occured
 	Op string `json:"op"`
 
 	// Msg is the user-friendly message returned to the client.
 	Msg []string `json:"message"`
 
+	// InternalMsg is the message for developers. It is printed by Error but never sent to clients, see SafeError.
+	InternalMsg []string `json:"-"`
+
 	// Details is the internal error message returned to the developer.
 	Details []any `json:"-"`
 
+	// Attrs are the attributes of the error, such as the identifiers of the entities involved, see Builder.With.
+	Attrs []slog.Attr `json:"-"`
+
+	// Fields are the violations of fields of a request, see Builder.Field.
+	Fields []FieldViolation `json:"fields,omitempty"`
+
 	// Code is the error code of the error. When marshaled to JSON, it will be a string.
 	Code Code `json:"code"`
 
+	// retryAfter is the duration clients should wait before retrying, see Builder.RetryAfter
+	retryAfter time.Duration
+
 	// show is a flag that indicates whether the error would be visible when wrapped by another error
 	show bool
 
 	// depth of the error tree
 	depth      int
 	shownDepth int
+
+	// pcs are the program counters of the stack trace captured when the error was created
+	pcs []uintptr
+
+	// origin is the error this error was derived from with B, see derive
+	origin *Error
+
+	// sentinel is set for errors declared with Sentinel
+	sentinel *sentinel
 }
 
 // knownCode returns the first known code of the error and all underlying errors
@@ -78,13 +107,86 @@ func (e *Error) writeTo(w io.StringWriter) {
 		w.WriteString(Separator + e.Op)
 	}
 
-	msgs := strings.Join(cleanStrings(e.Msg), Separator)
+	msgs := strings.Join(append(cleanStrings(e.Msg), cleanStrings(e.InternalMsg)...), Separator)
 	if len(msgs) > 0 {
 		w.WriteString(Separator + msgs)
 	}
 }
 
+// Format implements the fmt.Formatter interface.
+//
+// - %v and %s print the error returned by Error.
+//
+// - %+v prints the description of the error and ALL underlying errors returned by Stack, including details and stack traces.
+//
+// - %q prints the error returned by Error as a quoted string, %+q uses ASCII only.
+//
+// - %#v prints the Go syntax representation returned by GoString.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, e.Stack())
+	case verb == 'v' && s.Flag('#'):
+		io.WriteString(s, e.GoString())
+	case verb == 'q' && s.Flag('+'):
+		io.WriteString(s, strconv.QuoteToASCII(e.Error()))
+	case verb == 'q':
+		io.WriteString(s, strconv.Quote(e.Error()))
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// GoString implements the fmt.GoStringer interface and returns a Go syntax representation of the error
+// and all underlying errors. Fields with zero values are omitted.
+func (e *Error) GoString() string {
+	if e == nil {
+		return "(*errs.Error)(nil)"
+	}
+
+	fields := []string{"Code:" + e.Code.GoString()}
+	if e.Op != "" {
+		fields = append(fields, fmt.Sprintf("Op:%q", e.Op))
+	}
+	if e.Msg != nil {
+		fields = append(fields, fmt.Sprintf("Msg:%#v", e.Msg))
+	}
+	if e.InternalMsg != nil {
+		fields = append(fields, fmt.Sprintf("InternalMsg:%#v", e.InternalMsg))
+	}
+	if e.Details != nil {
+		fields = append(fields, fmt.Sprintf("Details:%#v", e.Details))
+	}
+	if e.Attrs != nil {
+		fields = append(fields, "Attrs:"+attrsGoString(e.Attrs))
+	}
+	if e.Fields != nil {
+		fields = append(fields, fmt.Sprintf("Fields:%#v", e.Fields))
+	}
+	if e.show {
+		fields = append(fields, "show:true")
+	}
+	if e.cause != nil {
+		fields = append(fields, "cause:"+e.cause.GoString())
+	}
+	if e.foreign != nil {
+		fields = append(fields, fmt.Sprintf("foreign:%#v", e.foreign))
+	}
+	return "&errs.Error{" + strings.Join(fields, ", ") + "}"
+}
+
+// Depth returns the number of underlying errors of the error, shown or not.
+func (e *Error) Depth() int {
+	return e.depth
+}
+
+// Shown reports whether the error is shown when it is wrapped by another error.
+func (e *Error) Shown() bool {
+	return e.show
+}
+
 // Stack returns a description of the error and all it's underlying errors.
+// The details, attributes, field violations and captured stack trace of each error are printed after it.
 func (e *Error) Stack() string {
 	var buf strings.Builder
 	for i, er := range all(e) {
@@ -97,6 +199,16 @@ func (e *Error) Stack() string {
 		for dx, d := range er.Details {
 			write(fmt.Sprintf("\t%d: %v\n", dx, d))
 		}
+		for _, a := range er.Attrs {
+			write(fmt.Sprintf("\t%s=%v\n", a.Key, a.Value))
+		}
+		for _, f := range er.Fields {
+			write(fmt.Sprintf("\t%s: %s: %s\n", f.Field, f.Code, f.Description))
+		}
+		for _, f := range er.Frames() {
+			write(fmt.Sprintf("\t%s\n", f.Function))
+			write(fmt.Sprintf("\t\t%s:%d\n", f.File, f.Line))
+		}
 		buf.WriteString("\n")
 	}
 
@@ -104,11 +216,32 @@ func (e *Error) Stack() string {
 }
 
 // Unwrap returns the underlying error.
+// For nodes converted from an error that is not an *Error (see Convert), the original error is returned.
+// It wraps the underlying *Error of the node, if any.
 func (e *Error) Unwrap() error {
-	if e.cause == nil {
+	switch {
+	case e.foreign != nil:
+		return e.foreign
+	case e.cause != nil:
+		return e.cause
+	default:
 		return nil
 	}
-	return e.cause
+}
+
+// joined is the original error of a node that is given an underlying *Error the original error does not wrap,
+// see Wrap. It unwraps to both, so that errors.Is and errors.As still match them.
+type joined struct {
+	cause   *Error
+	foreign error
+}
+
+func (j *joined) Error() string {
+	return j.foreign.Error()
+}
+
+func (j *joined) Unwrap() []error {
+	return []error{j.cause, j.foreign}
 }
 
 func (e *Error) wrap(inner *Error) {
@@ -124,19 +257,52 @@ func (e *Error) wrap(inner *Error) {
 	e.Code = e.knownCode()
 }
 
+// Is reports whether the error matches target. Errors match when their nodes are equal, see equalNodes.
+// Errors derived from another error with B also match the error they were derived from, and the errors that one
+// was derived from in turn.
+//
+// Targets that are sentinels are matched with the MatchMode of the sentinel, see Sentinel. Targets derived from
+// a sentinel are not sentinels: they match the errors derived from them and the errors equal to them.
 func (e *Error) Is(target error) bool {
 	var t *Error
-	if errors.As(target, &t) {
-		return equalNodes(e, t)
+	if !errors.As(target, &t) {
+		return false
+	}
+	if match, ok := e.matchSentinel(t); ok {
+		return match
+	}
+	if equalNodes(e, t) {
+		return true
+	}
+	for o := e.origin; o != nil; o = o.origin {
+		if o == t || equalNodes(o, t) {
+			return true
+		}
 	}
 	return false
 }
 
+// derive returns a copy of the error that can be modified without changing the error.
+// The copy keeps the error as its origin, so that it still matches it with errors.Is.
+// The stack trace is not copied, the copy captures its own.
+func (e *Error) derive() *Error {
+	d := *e
+	d.pcs = nil
+	d.Msg = slices.Clone(e.Msg)
+	d.InternalMsg = slices.Clone(e.InternalMsg)
+	d.Details = slices.Clone(e.Details)
+	d.Attrs = slices.Clone(e.Attrs)
+	d.Fields = slices.Clone(e.Fields)
+	d.sentinel = nil
+	d.origin = e
+	return &d
+}
+
 // equalNodes was created because we can't even trust go to compare equality of the error structs.
 // Comparison does not involve the underlying errors because we don't want to compare the entire error tree.
 //
-// The fields considered for equality are error codes and messages. It makes sense to leave details out because two errors
-// might be the same but with different details.
+// The fields considered for equality are error codes, operations and messages, including internal messages.
+// It makes sense to leave details out because two errors might be the same but with different details.
 func equalNodes(a, b *Error) bool {
 	if a == nil && b == nil {
 		return true
@@ -158,7 +324,7 @@ func equalNodes(a, b *Error) bool {
 			return false
 		}
 	}
-	return true
+	return slices.Equal(a.InternalMsg, b.InternalMsg)
 }
 
 // WrapMsg wraps an underlying error with a new error, adding message to the error's previously existing message
@@ -168,10 +334,11 @@ func WrapMsg(err error, message ...string) error {
 	if er != nil {
 		code = er.Code
 	}
-	return WrapCode(er, code, message...)
+	return wrapCode(er, code, message, 1)
 }
 
 // Wrap wraps an underlying error `child` with a new error `parent`.
+// The returned error is a copy of parent that still matches it with errors.Is, parent itself is never modified.
 //
 // - when the child error is nil, the parent error is returned as is.
 //
@@ -189,39 +356,83 @@ func Wrap(child, parent error) error {
 	case c == nil:
 		return p
 	default:
+		if p == parent {
+			// the parent may be shared, e.g. declared as a package variable, and must never be modified
+			p = p.derive()
+		}
 		p.wrap(c)
+		if p.foreign != nil {
+			// the original error of the parent does not wrap the child
+			p.foreign = &joined{cause: c, foreign: p.foreign}
+		}
+		p.capture(1, false)
 		return p
 	}
 }
 
 // Convert converts any error to an *Error type. If the error is already an *Error, it is returned as is.
 // nil errors are returned as nil.
+//
+// Other errors are kept as the underlying error of the returned *Error, so errors.Is and errors.As
+// still match them after they have been wrapped. This includes errors that wrap several errors, such as
+// *MultiError or the result of errors.Join. Their code is returned by CodeOf, which derives the code of
+// a *MultiError and falls back to the classifiers, see RegisterClassifier. Their text is the internal message of the returned *Error, so it is never
+// sent to clients.
+//
+// Errors that wrap an *Error, such as fmt.Errorf("reading config: %w", err), keep it as the underlying *Error of
+// the returned *Error, whose internal message is then the text added by the wrapper, "reading config".
 func Convert(err error) error {
 	if err == nil {
 		return nil
 	}
-	var e *Error
-	if errors.As(err, &e) {
+	if e, ok := err.(*Error); ok {
 		return e
 	}
-	return &Error{
-		Code: Unknown,
-		Msg:  []string{err.Error()},
+	e := &Error{
+		Code:        CodeOf(err),
+		InternalMsg: []string{err.Error()},
+		foreign:     err,
+	}
+	if inner := wrapped(err); inner != nil {
+		e.InternalMsg = cleanStrings([]string{wrapperText(err, inner)})
+		e.wrap(inner)
+	}
+	return e
+}
+
+// wrapped returns the first *Error in the chain of errors wrapped by err, or nil.
+func wrapped(err error) *Error {
+	for err = errors.Unwrap(err); err != nil; err = errors.Unwrap(err) {
+		if e, ok := err.(*Error); ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// wrapperText returns the text that err adds to the text of the *Error it wraps, or the text of err
+// if it does not end with the text of inner.
+func wrapperText(err error, inner *Error) string {
+	text, ok := strings.CutSuffix(err.Error(), inner.Error())
+	if !ok {
+		return err.Error()
 	}
+	return strings.TrimSpace(strings.TrimSuffix(text, Separator))
 }
 
 // WrapCode wraps an underlying error with a new error, adding message to the error's previously existing message and setting the error code to code.
 func WrapCode(err error, code Code, messages ...string) error {
-	er := convert(err)
-	if er == nil {
-		return B().Code(code).Msg(cleanStrings(messages)...).Err()
-	}
+	return wrapCode(err, code, messages, 1)
+}
 
+// wrapCode implements WrapCode, skip is the number of frames to skip above the caller of wrapCode when tracing.
+func wrapCode(err error, code Code, messages []string, skip int) *Error {
 	e := &Error{
 		Code: code,
 		Msg:  cleanStrings(messages),
 	}
-	e.wrap(er)
+	e.wrap(convert(err))
+	e.capture(skip+1, false)
 	return e
 }
 
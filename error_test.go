@@ -3,13 +3,17 @@ package errs
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestWrap(t *testing.T) {
+	parentErr, childErr := errors.New("parent"), errors.New("child")
+	parent := B().Code(Internal).Msg("parent").Op("UserRepository.CreateUser").Err()
 	testcases := []struct {
 		name          string
 		child, parent error
@@ -18,17 +22,19 @@ func TestWrap(t *testing.T) {
 		{
 			name:   "nil child",
 			child:  nil,
-			parent: errors.New("parent"),
+			parent: parentErr,
 			expect: &Error{
-				Msg: []string{"parent"},
+				InternalMsg: []string{"parent"},
+				foreign:     parentErr,
 			},
 		},
 		{
 			name:   "nil parent",
-			child:  errors.New("child"),
+			child:  childErr,
 			parent: nil,
 			expect: &Error{
-				Msg: []string{"child"},
+				InternalMsg: []string{"child"},
+				foreign:     childErr,
 			},
 		},
 		{
@@ -40,7 +46,7 @@ func TestWrap(t *testing.T) {
 		{
 			name:   "child and parent are provided",
 			child:  B().Code(NotFound).Msg("child").Err(),
-			parent: B().Code(Internal).Msg("parent").Op("UserRepository.CreateUser").Err(),
+			parent: parent,
 			expect: &Error{
 				Code: Internal,
 				Msg:  []string{"parent"},
@@ -49,7 +55,8 @@ func TestWrap(t *testing.T) {
 					Code: NotFound,
 					Msg:  []string{"child"},
 				},
-				depth: 1,
+				depth:  1,
+				origin: parent.(*Error),
 			},
 		},
 	}
@@ -58,6 +65,27 @@ func TestWrap(t *testing.T) {
 			assert.Equal(t, tc.expect, Wrap(tc.child, tc.parent))
 		})
 	}
+	t.Run("shared parent is never modified", func(t *testing.T) {
+		shared := B().Code(NotFound).Msg("not found").Err()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				child := fmt.Errorf("query %d: %w", i, io.EOF)
+				err := Wrap(child, shared)
+				assert.ErrorIs(t, err, shared)
+				assert.ErrorIs(t, err, child)
+				assert.Equal(t, 1, err.(*Error).Depth())
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, "not_found: not found", shared.Error())
+		assert.Equal(t, 0, shared.(*Error).Depth())
+		assert.Nil(t, errors.Unwrap(shared))
+		assert.NotErrorIs(t, shared, io.EOF)
+	})
 }
 
 func TestWrapMsg(t *testing.T) {
@@ -250,12 +278,12 @@ func TestWrapCode(t *testing.T) {
 				code:    Unavailable,
 				message: "new error",
 			},
-			wantErr: func(_ args) error {
+			wantErr: func(x args) error {
 				return &Error{
 					Code:  Unavailable,
 					Msg:   []string{"new error"},
 					depth: 1,
-					cause: B().Msg("not our error").Err().(*Error),
+					cause: &Error{InternalMsg: []string{"not our error"}, foreign: x.err},
 				}
 			},
 		},
@@ -267,6 +295,58 @@ func TestWrapCode(t *testing.T) {
 	}
 }
 
+type queryError struct{ query string }
+
+func (e *queryError) Error() string { return "query failed: " + e.query }
+
+func TestConvert_foreign(t *testing.T) {
+	t.Run("errors.Is matches the foreign error", func(t *testing.T) {
+		err := WrapMsg(io.EOF, "reading body")
+		assert.ErrorIs(t, err, io.EOF)
+		assert.ErrorIs(t, WrapCode(err, Internal, "handler failed"), io.EOF)
+		assert.ErrorIs(t, Wrap(io.EOF, B().Code(Internal).Err()), io.EOF)
+		assert.ErrorIs(t, WrapB(io.EOF).Msg("reading body").Err(), io.EOF)
+	})
+	t.Run("errors.As reaches the foreign error", func(t *testing.T) {
+		err := WrapCode(&queryError{query: "SELECT 1"}, Internal, "db failed")
+
+		var qErr *queryError
+		assert.ErrorAs(t, err, &qErr)
+		assert.Equal(t, "SELECT 1", qErr.query)
+	})
+	t.Run("message of the foreign error is kept", func(t *testing.T) {
+		err := Convert(io.EOF)
+		assert.Equal(t, "unknown: EOF", err.Error())
+		assert.Equal(t, io.EOF, errors.Unwrap(err))
+	})
+	t.Run("foreign parent", func(t *testing.T) {
+		child, parent := B().Code(NotFound).Msg("user not found").Err(), errors.New("parent")
+		err := Wrap(child, parent)
+		assert.ErrorIs(t, err, parent)
+		assert.ErrorIs(t, err, child)
+		assert.ErrorIs(t, errors.Unwrap(err), child, "the original error unwraps to the child")
+		assert.Equal(t, NotFound, err.(*Error).Code)
+	})
+	t.Run("mixed chain", func(t *testing.T) {
+		inner := B().Code(NotFound).Op("Users.Get").Msg("user not found").Show().Err()
+		outer := fmt.Errorf("loading profile: %w", inner)
+		err := WrapMsg(outer, "top")
+
+		assert.ErrorIs(t, err, outer)
+		assert.ErrorIs(t, err, inner)
+		assert.Equal(t, NotFound, CodeOf(err))
+		assert.Equal(t, 2, err.(*Error).Depth())
+		assert.Equal(t, "not_found: top\nnot_found: Users.Get: user not found", err.Error())
+		assert.Equal(t, "not_found: top\n\n"+
+			"\tnot_found: loading profile\n\n"+
+			"\t\tnot_found: Users.Get: user not found\n\n", err.(*Error).Stack())
+
+		b := B(outer).Msg("copy").Err()
+		assert.ErrorIs(t, b, outer)
+		assert.Equal(t, "not_found: copy: loading profile\nnot_found: Users.Get: user not found", b.Error())
+	})
+}
+
 func ExampleError_Stack() {
 	err1 := B().Code(NotFound).
 		Msg("item not found").
@@ -373,3 +453,39 @@ func BenchmarkWrapCode(b *testing.B) {
 	}
 	e = _e
 }
+
+func TestError_Format(t *testing.T) {
+	err := Wrap(
+		B().Code(NotFound).Msg(`item "42" not found`).Details("id", 42).Show().Err(),
+		B().Code(Internal).Op("FetchItem").Msg("internal error").Err(),
+	)
+
+	testcases := []struct {
+		format string
+		expect string
+	}{
+		{"%v", "internal: FetchItem: internal error\nnot_found: item \"42\" not found"},
+		{"%s", "internal: FetchItem: internal error\nnot_found: item \"42\" not found"},
+		{"%+v", err.(*Error).Stack()},
+		{"%q", `"internal: FetchItem: internal error\nnot_found: item \"42\" not found"`},
+		{"%#v", `&errs.Error{Code:errs.Internal, Op:"FetchItem", Msg:[]string{"internal error"}, ` +
+			`cause:&errs.Error{Code:errs.NotFound, Msg:[]string{"item \"42\" not found"}, Details:[]interface {}{"id", 42}, show:true}}`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.format, func(t *testing.T) {
+			assert.Equal(t, tc.expect, fmt.Sprintf(tc.format, err))
+		})
+	}
+
+	t.Run("%+q", func(t *testing.T) {
+		assert.Equal(t, `"unknown: caf\u00e9"`, fmt.Sprintf("%+q", B().Msg("café").Err()))
+	})
+	t.Run("%#v foreign", func(t *testing.T) {
+		assert.Equal(t, `&errs.Error{Code:errs.Unknown, InternalMsg:[]string{"EOF"}, foreign:&errors.errorString{s:"EOF"}}`,
+			fmt.Sprintf("%#v", Convert(io.EOF)))
+	})
+	t.Run("%#v nil", func(t *testing.T) {
+		var err *Error
+		assert.Equal(t, "(*errs.Error)(nil)", fmt.Sprintf("%#v", err))
+	})
+}
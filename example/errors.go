@@ -1,6 +1,8 @@
 package example
 
 import (
+	"log/slog"
+
 	"github.com/lordvidex/errs/v2"
 	"google.golang.org/grpc/codes"
 )
@@ -15,6 +17,19 @@ func init() {
 }
 
 func Register() {
-	errs.RegisterCode(CustomErr, 400, codes.AlreadyExists, "This is custom error")
-	errs.RegisterCode(SecondErr, 399, codes.DataLoss, "This is another error")
+	errs.RegisterCodeSpec(CustomErr, errs.CodeSpec{
+		Name:          "custom_error",
+		Description:   "This is custom error",
+		HTTP:          400,
+		Parent:        errs.AlreadyExists,
+		PublicMessage: "the resource already exists",
+		DocURL:        "https://example.com/errors/custom_error",
+	})
+	errs.RegisterCodeSpec(SecondErr, errs.CodeSpec{
+		Name:        "second_error",
+		Description: "This is another error",
+		HTTP:        399,
+		GRPC:        codes.DataLoss,
+		LogLevel:    slog.LevelWarn,
+	})
 }
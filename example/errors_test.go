@@ -1,22 +1,46 @@
 package example
 
 import (
+	"log/slog"
 	"testing"
 
 	"github.com/lordvidex/errs/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 )
 
 func TestRegister(t *testing.T) {
 	t.Run("testing registration", func(t *testing.T) {
 		assert.True(t, errs.IsRegistered(CustomErr))
 		assert.True(t, errs.IsRegistered(SecondErr))
+		assert.NoError(t, errs.ValidateCodes(CustomErr, SecondErr))
 	})
 
 	t.Run("testing tojson", func(t *testing.T) {
 		byt, err := CustomErr.MarshalJSON()
 		require.NoError(t, err)
-		assert.Equal(t, []byte(`"This is custom error"`), byt)
+		assert.Equal(t, []byte(`"custom_error"`), byt)
+
+		var c errs.Code
+		require.NoError(t, c.UnmarshalJSON(byt))
+		assert.Equal(t, CustomErr, c)
+	})
+
+	t.Run("testing specs", func(t *testing.T) {
+		assert.Equal(t, "custom_error", CustomErr.Name())
+		assert.Equal(t, "This is custom error", CustomErr.Description())
+		assert.Equal(t, 400, CustomErr.HTTP())
+		assert.Equal(t, codes.AlreadyExists, CustomErr.GRPC(), "inherited from the parent")
+		assert.True(t, CustomErr.Is(errs.AlreadyExists))
+		assert.Equal(t, errs.SeverityWarning, CustomErr.Severity())
+		assert.Equal(t, "https://example.com/errors/custom_error", CustomErr.DocURL())
+
+		err := errs.B().Code(CustomErr).Err().(*errs.Error)
+		assert.Equal(t, "custom_error: the resource already exists", err.SafeError())
+
+		assert.Equal(t, errs.SeverityCritical, SecondErr.Severity())
+		assert.Equal(t, slog.LevelWarn, SecondErr.LogLevel())
+		assert.False(t, SecondErr.Retryable())
 	})
 }
@@ -0,0 +1,64 @@
+package errs
+
+// FieldViolation describes a field of a request that is not valid.
+type FieldViolation struct {
+	// Field is the path of the field, nested fields are separated by dots e.g. "address.zip".
+	Field string `json:"field"`
+
+	// Code is the code of the violation.
+	Code Code `json:"code"`
+
+	// Description is the user-friendly description of the violation.
+	Description string `json:"description"`
+}
+
+// Field adds a violation of field with the InvalidArgument code to the error.
+// If the code of the error is Unknown, it is set to InvalidArgument.
+func (b *Builder) Field(field, description string) *Builder {
+	return b.FieldCode(field, InvalidArgument, description)
+}
+
+// FieldCode adds a violation of field with the given code to the error.
+// If the code of the error is Unknown, it is set to InvalidArgument.
+func (b *Builder) FieldCode(field string, code Code, description string) *Builder {
+	if b.err.Code == Unknown {
+		b.err.Code = InvalidArgument
+	}
+	b.err.Fields = append(b.err.Fields, FieldViolation{Field: field, Code: code, Description: description})
+	return b
+}
+
+// Fields adds the violations of err, returned by FieldViolations, to the error with their fields prefixed by prefix.
+// It is useful to merge the violations of nested structures e.g. prefix "address" turns "zip" into "address.zip".
+// If the code of the error is Unknown and violations are added, it is set to InvalidArgument.
+func (b *Builder) Fields(prefix string, err error) *Builder {
+	for _, v := range FieldViolations(err) {
+		b.FieldCode(joinField(prefix, v.Field), v.Code, v.Description)
+	}
+	return b
+}
+
+// FieldViolations returns the field violations of err and all underlying errors, including the errors of
+// a MultiError or errors.Join. Errors that are not *Error have no violations.
+func FieldViolations(err error) []FieldViolation {
+	var result []FieldViolation
+	walk(err, func(err error) bool {
+		if x, ok := err.(*Error); ok {
+			result = append(result, x.Fields...)
+		}
+		return true
+	})
+	return result
+}
+
+// joinField returns the path of field nested in prefix.
+func joinField(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	default:
+		return prefix + "." + field
+	}
+}
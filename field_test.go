@@ -0,0 +1,92 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/lordvidex/errs/v2/internal/errspb"
+)
+
+func TestBuilder_Field(t *testing.T) {
+	t.Run("code defaults to InvalidArgument", func(t *testing.T) {
+		err := B().Field("email", "must be a valid address").Err().(*Error)
+		assert.Equal(t, InvalidArgument, err.Code)
+		assert.Equal(t, []FieldViolation{{Field: "email", Code: InvalidArgument, Description: "must be a valid address"}}, err.Fields)
+	})
+	t.Run("code is kept", func(t *testing.T) {
+		err := B().Code(FailedPrecondition).FieldCode("email", AlreadyExists, "is taken").Err().(*Error)
+		assert.Equal(t, FailedPrecondition, err.Code)
+		assert.Equal(t, []FieldViolation{{Field: "email", Code: AlreadyExists, Description: "is taken"}}, err.Fields)
+	})
+	t.Run("nested fields", func(t *testing.T) {
+		zip := B().Field("zip", "must have 5 digits").Err()
+		address := WrapB(B().Field("", "is required").Err()).Msg("invalid address").Err()
+		items := MultiB().
+			Add(B().Fields("[0]", B().Field("name", "is required").Err()).Err()).
+			Add(B().Fields("[1]", B().Field("count", "must be positive").Err()).Err()).
+			ErrOrNil()
+
+		err := B().
+			Fields("address", zip).
+			Fields("billing", address).
+			Fields("items", items).
+			Fields("ignored", errors.New("not our error")).
+			Err()
+		assert.Equal(t, []FieldViolation{
+			{Field: "address.zip", Code: InvalidArgument, Description: "must have 5 digits"},
+			{Field: "billing", Code: InvalidArgument, Description: "is required"},
+			{Field: "items.[0].name", Code: InvalidArgument, Description: "is required"},
+			{Field: "items.[1].count", Code: InvalidArgument, Description: "must be positive"},
+		}, FieldViolations(err))
+	})
+}
+
+func TestFieldViolations(t *testing.T) {
+	assert.Nil(t, FieldViolations(nil))
+	assert.Nil(t, FieldViolations(B().Code(NotFound).Err()))
+
+	inner := B().Field("name", "is required").Err()
+	assert.Equal(t, []FieldViolation{{Field: "name", Code: InvalidArgument, Description: "is required"}},
+		FieldViolations(fmt.Errorf("validating: %w", WrapCode(inner, Unknown, "invalid request"))))
+}
+
+func TestError_Fields_encoding(t *testing.T) {
+	err := WrapB(B().Field("email", "must be a valid address").Show().Err()).
+		Msg("invalid request").
+		Field("name", "is required").
+		Err().(*Error)
+
+	t.Run("json", func(t *testing.T) {
+		byt, e := json.Marshal(err)
+		require.NoError(t, e)
+		assert.JSONEq(t, `{"op":"","message":["invalid request"],"code":"invalid_argument",`+
+			`"fields":[{"field":"name","code":"invalid_argument","description":"is required"}],"causes":[`+
+			`{"op":"","message":null,"code":"invalid_argument",`+
+			`"fields":[{"field":"email","code":"invalid_argument","description":"must be a valid address"}]}]}`, string(byt))
+
+		decoded := new(Error)
+		require.NoError(t, json.Unmarshal(byt, decoded))
+		assert.Equal(t, FieldViolations(err), FieldViolations(decoded))
+	})
+	t.Run("grpc", func(t *testing.T) {
+		details := err.GRPCStatus().Details()
+		require.Len(t, details, 4)
+		assert.Len(t, details[2].(*errspb.Error).GetFields(), 1)
+
+		br := details[3].(*errdetails.BadRequest)
+		require.Len(t, br.GetFieldViolations(), 2)
+		assert.Equal(t, "name", br.GetFieldViolations()[0].GetField())
+		assert.Equal(t, "email", br.GetFieldViolations()[1].GetField())
+		assert.Equal(t, "must be a valid address", br.GetFieldViolations()[1].GetDescription())
+	})
+	t.Run("stack", func(t *testing.T) {
+		assert.Equal(t, "invalid_argument: invalid request\n\tname: invalid_argument: is required\n\n"+
+			"\tinvalid_argument\n\t\temail: invalid_argument: must be a valid address\n\n", err.Stack())
+	})
+}
@@ -1,10 +1,109 @@
 package errs
 
 import (
+	"slices"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/lordvidex/errs/v2/internal/errspb"
 )
 
+// ErrorDomain is the domain of the errdetails.ErrorInfo attached to gRPC statuses by GRPCStatus.
+var ErrorDomain = "github.com/lordvidex/errs"
+
 // GRPCStatus returns a *status.Status representation of *errs.Error
+//
+// The message of the status is returned by SafeError, and so are the details: internal messages are never
+// sent, and neither are the operations of errors with redacted codes.
+//
+// The status carries the error and its SHOWN underlying errors as details:
+//
+// - errdetails.ErrorInfo with the code as reason and the operation in the "op" metadata.
+//
+// - errdetails.DebugInfo with one stack entry per shown error.
+//
+// - errdetails.BadRequest with the field violations of the shown errors, if any.
+//
+// - errdetails.RetryInfo with the duration returned by RetryAfter, if any.
+//
+// - the errs tree itself, which can be decoded with status.ToErrs from the errs/status package.
 func (e *Error) GRPCStatus() *status.Status {
-	return status.New(e.knownCode().GRPC(), e.Error())
+	code := e.knownCode()
+	st := status.New(code.GRPC(), e.SafeError())
+
+	info := &errdetails.ErrorInfo{Reason: code.String(), Domain: ErrorDomain}
+	if op := e.SafeOp(); op != "" {
+		info.Metadata = map[string]string{"op": op}
+	}
+	debug := &errdetails.DebugInfo{StackEntries: []string{e.safeString()}}
+	for er := range shown(e.cause) {
+		debug.StackEntries = append(debug.StackEntries, er.safeString())
+	}
+
+	details := []protoadapt.MessageV1{info, debug, e.proto()}
+	if br := badRequest(e.shownFields()); br != nil {
+		details = append(details, br)
+	}
+	if ri := retryInfo(e); ri != nil {
+		details = append(details, ri)
+	}
+
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// shownFields returns the field violations of the error and its shown underlying errors.
+func (e *Error) shownFields() []FieldViolation {
+	fields := slices.Clip(e.Fields)
+	for er := range shown(e.cause) {
+		fields = append(fields, er.Fields...)
+	}
+	return fields
+}
+
+// badRequest returns the errdetails.BadRequest with the field violations, or nil if there are none.
+func badRequest(fields []FieldViolation) *errdetails.BadRequest {
+	if len(fields) == 0 {
+		return nil
+	}
+	br := new(errdetails.BadRequest)
+	for _, f := range fields {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Description,
+		})
+	}
+	return br
+}
+
+// retryInfo returns the errdetails.RetryInfo with the duration returned by RetryAfter for err, or nil if there is none.
+func retryInfo(err error) *errdetails.RetryInfo {
+	d, ok := RetryAfter(err)
+	if !ok {
+		return nil
+	}
+	return &errdetails.RetryInfo{RetryDelay: durationpb.New(d)}
+}
+
+// proto returns the protobuf representation of the error and its shown underlying errors.
+func (e *Error) proto() *errspb.Error {
+	pb := e.protoNode()
+	for er := range shown(e.cause) {
+		pb.Causes = append(pb.Causes, er.protoNode())
+	}
+	return pb
+}
+
+func (e *Error) protoNode() *errspb.Error {
+	pb := &errspb.Error{Code: e.Code.String(), Op: e.SafeOp(), Message: e.safeMsg()}
+	for _, f := range e.Fields {
+		pb.Fields = append(pb.Fields, &errspb.FieldViolation{Field: f.Field, Code: f.Code.String(), Description: f.Description})
+	}
+	return pb
 }
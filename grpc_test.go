@@ -0,0 +1,56 @@
+package errs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+
+	"github.com/lordvidex/errs/v2/internal/errspb"
+)
+
+func TestError_GRPCStatus(t *testing.T) {
+	err := Wrap(
+		Wrap(
+			B().Code(Aborted).Op("Postgres").Msg("connection failed").Show().Err(),
+			B().Code(Internal).Op("Cache").Msg("cache miss").Err(),
+		),
+		B().Code(NotFound).Op("FetchItem").Msg("item not found").Err(),
+	).(*Error)
+
+	st := err.GRPCStatus()
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, err.Error(), st.Message())
+
+	details := st.Details()
+	require.Len(t, details, 3)
+	assert.Equal(t, "not_found", details[0].(*errdetails.ErrorInfo).GetReason())
+	assert.Equal(t, ErrorDomain, details[0].(*errdetails.ErrorInfo).GetDomain())
+	assert.Equal(t, map[string]string{"op": "FetchItem"}, details[0].(*errdetails.ErrorInfo).GetMetadata())
+	assert.Equal(t, []string{
+		"not_found: FetchItem: item not found",
+		"aborted: Postgres: connection failed",
+	}, details[1].(*errdetails.DebugInfo).GetStackEntries())
+
+	pb := details[2].(*errspb.Error)
+	assert.Equal(t, "not_found", pb.GetCode())
+	assert.Equal(t, "FetchItem", pb.GetOp())
+	assert.Equal(t, []string{"item not found"}, pb.GetMessage())
+	require.Len(t, pb.GetCauses(), 1)
+	assert.Equal(t, "aborted", pb.GetCauses()[0].GetCode())
+	assert.Equal(t, "Postgres", pb.GetCauses()[0].GetOp())
+	assert.Equal(t, []string{"connection failed"}, pb.GetCauses()[0].GetMessage())
+}
+
+func TestError_GRPCStatus_registeredCode(t *testing.T) {
+	custom := Code(CodeSize + 12)
+	RegisterCodeSpec(custom, CodeSpec{Name: "payment_declined", Description: "The payment was declined", Parent: FailedPrecondition})
+	defer UnregisterCode(custom)
+
+	st := B().Code(custom).Msg("card expired").Err().(*Error).GRPCStatus()
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Equal(t, "payment_declined: card expired", st.Message())
+	assert.Equal(t, "payment_declined", st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+}
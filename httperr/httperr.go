@@ -0,0 +1,242 @@
+// Package httperr writes *errs.Error values as HTTP responses.
+//
+// The status code of a response is the HTTP code mapped to the first known code of the error chain, and the body
+// is negotiated with the Accept header of the request between JSON (the default), plain text and
+// RFC 9457 problem details.
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lordvidex/errs/v2"
+	"github.com/lordvidex/errs/v2/metrics"
+)
+
+// HandlerFunc is an HTTP handler that returns an error.
+// Returned errors are written to the response with WriteError.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP implements the http.Handler interface.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		WriteError(w, r, err)
+	}
+}
+
+// Option configures Handler and Recover.
+type Option func(*options)
+
+type options struct {
+	recorder metrics.Recorder
+	registry *errs.Registry
+}
+
+// WithRecorder sets a metrics.Recorder that records every error written to responses.
+// Errors without operation are recorded with the pattern of the request as operation, see http.Request.Pattern.
+func WithRecorder(r metrics.Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
+}
+
+// WithRegistry sets the errs.Registry that the codes of written errors are mapped to HTTP statuses and problem
+// details with, instead of the default registry. Names of codes in bodies come from the default registry,
+// see errs.Registry.
+func WithRegistry(reg *errs.Registry) Option {
+	return func(o *options) {
+		o.registry = reg
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{registry: errs.DefaultRegistry()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Handler returns an http.Handler that calls fn and writes the errors it returns with WriteError.
+// Unlike HandlerFunc, it can be configured with options.
+func Handler(fn HandlerFunc, opts ...Option) http.Handler {
+	o := newOptions(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			o.writeError(w, r, err)
+		}
+	})
+}
+
+// writeError records err and writes it to w.
+func (o *options) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if o.recorder != nil {
+		obs := metrics.Observe(metrics.HTTP, err)
+		if obs.Op == "" {
+			obs.Op = r.Pattern
+		}
+		o.recorder.Record(r.Context(), obs)
+	}
+	o.write(w, r, err)
+}
+
+// WriteError writes err to w. The first *errs.Error wrapped by err is written, errors that do not wrap any are
+// converted with errs.Convert, except for an *errs.MultiError that no *errs.Error wraps, which is written with its
+// derived code.
+// Bodies only contain the information returned by SafeError, see errs.RenderMode.
+// The Retry-After header is set when err has a retry hint, see errs.RetryAfter.
+// When err is nil, WriteError is a no-op.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	newOptions(nil).write(w, r, err)
+}
+
+// write writes err to w with the codes mapped by the registry of o, see WriteError.
+func (o *options) write(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+
+	var (
+		body   safeError
+		status int
+	)
+	if m, ok := asMulti(err); ok {
+		body, status = m, o.registry.HTTP(m.Code())
+	} else {
+		e := asError(err)
+		body, status = e, o.registry.HTTP(errs.CodeOf(e))
+	}
+
+	setRetryAfter(w, err)
+	switch negotiate(r) {
+	case contentProblem:
+		WriteProblem(w, r, body, WithProblemRegistry(o.registry))
+	case contentText:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body.SafeError())
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+// asError returns the first *errs.Error in the tree of err, or err converted with errs.Convert.
+// Errors wrapping an *errs.Error only add internal context, so the *errs.Error is what clients see.
+func asError(err error) *errs.Error {
+	var e *errs.Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return errs.Convert(err).(*errs.Error)
+}
+
+// asMulti returns the *errs.MultiError in the tree of err when no *errs.Error wraps it.
+// An *errs.Error wrapping a MultiError is written with its own code and messages.
+func asMulti(err error) (*errs.MultiError, bool) {
+	var m *errs.MultiError
+	if !errors.As(err, &m) {
+		return nil, false
+	}
+	// the first *errs.Error is one of the errors of m when no *errs.Error wraps it
+	var e *errs.Error
+	return m, !errors.As(err, &e) || slices.Contains(m.Errors(), e)
+}
+
+// setRetryAfter sets the Retry-After header to the duration returned by errs.RetryAfter for err, in seconds.
+func setRetryAfter(w http.ResponseWriter, err error) {
+	if d, ok := errs.RetryAfter(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	}
+}
+
+// retryAfter returns the duration of the Retry-After header of resp, only delays in seconds are supported.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// safeError is implemented by *errs.Error and *errs.MultiError.
+type safeError interface {
+	error
+	SafeError() string
+}
+
+// Recover returns a middleware that recovers from panics in next and writes them as errs.Internal errors.
+// The recovered value is kept in the details of the error, its operation is the pattern of the request, see
+// http.Request.Pattern, and the path of the request is kept in its "path" attribute.
+func Recover(next http.Handler, opts ...Option) http.Handler {
+	o := newOptions(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if p == http.ErrAbortHandler {
+				// the server handles this panic on its own
+				panic(p)
+			}
+			// the operation is the pattern of the request, the path may contain identifiers and is only an attribute
+			err := errs.B().Code(errs.Internal).Op(r.Pattern).Details(fmt.Sprint(p)).With("path", r.URL.Path).Err()
+			o.writeError(w, r, err)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+type contentType int
+
+const (
+	contentJSON contentType = iota
+	contentText
+	contentProblem
+)
+
+// negotiate returns the content type with the highest quality in the Accept header of r.
+// JSON is used when the header is missing or none of the supported types is acceptable.
+func negotiate(r *http.Request) contentType {
+	best, bestQ := contentJSON, 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		var ct contentType
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			ct = contentJSON
+		case "text/plain", "text/*":
+			ct = contentText
+		case ProblemContentType:
+			ct = contentProblem
+		default:
+			continue
+		}
+		if q > bestQ {
+			best, bestQ = ct, q
+		}
+	}
+	return best
+}
@@ -0,0 +1,241 @@
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lordvidex/errs/v2"
+	"github.com/lordvidex/errs/v2/metrics"
+)
+
+func TestWriteError(t *testing.T) {
+	err := errs.WrapCode(
+		errs.B().Code(errs.NotFound).Msg("user not found").Show().Err(),
+		errs.Unknown, "fetching user",
+	)
+	testcases := []struct {
+		name        string
+		accept      string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "no accept header",
+			contentType: "application/json",
+			body:        `{"op":"","message":["fetching user"],"code":"not_found","causes":[{"op":"","message":["user not found"],"code":"not_found"}]}`,
+		},
+		{
+			name:        "json",
+			accept:      "application/json",
+			contentType: "application/json",
+			body:        `{"op":"","message":["fetching user"],"code":"not_found","causes":[{"op":"","message":["user not found"],"code":"not_found"}]}`,
+		},
+		{
+			name:        "plain text",
+			accept:      "text/plain",
+			contentType: "text/plain; charset=utf-8",
+			body:        "not_found: fetching user\nnot_found: user not found",
+		},
+		{
+			name:        "highest quality wins",
+			accept:      "application/json;q=0.5, text/*;q=0.9",
+			contentType: "text/plain; charset=utf-8",
+			body:        "not_found: fetching user\nnot_found: user not found",
+		},
+		{
+			name:        "unsupported types fall back to json",
+			accept:      "application/xml",
+			contentType: "application/json",
+			body:        `{"op":"","message":["fetching user"],"code":"not_found","causes":[{"op":"","message":["user not found"],"code":"not_found"}]}`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+			w := httptest.NewRecorder()
+
+			WriteError(w, r, err)
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+			if tc.contentType == "application/json" {
+				assert.JSONEq(t, tc.body, w.Body.String())
+			} else {
+				assert.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("nil error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		WriteError(w, httptest.NewRequest(http.MethodGet, "/", nil), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+	t.Run("wrapped by a foreign error", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		r.Header.Set("Accept", "text/plain")
+		w := httptest.NewRecorder()
+
+		WriteError(w, r, fmt.Errorf("handling request: %w", err))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "not_found: fetching user\nnot_found: user not found", w.Body.String())
+	})
+}
+
+func TestWriteError_RetryAfter(t *testing.T) {
+	err := errs.B().Code(errs.ResourceExhausted).Msg("slow down").RetryAfter(1500 * time.Millisecond).Err()
+	for _, accept := range []string{"application/json", "text/plain", ProblemContentType} {
+		t.Run(accept, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept", accept)
+			WriteError(w, r, err)
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+			assert.Equal(t, "2", w.Header().Get("Retry-After"))
+		})
+	}
+
+	w := httptest.NewRecorder()
+	WriteError(w, httptest.NewRequest(http.MethodGet, "/", nil), errs.B().Code(errs.Unavailable).Err())
+	assert.Empty(t, w.Header().Get("Retry-After"))
+}
+
+func TestHandlerFunc(t *testing.T) {
+	handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Query().Get("id") == "" {
+			return errs.B().Code(errs.InvalidArgument).Msg("id is required").Err()
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var got errs.Error
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, errs.InvalidArgument, got.Code)
+	assert.Equal(t, []string{"id is required"}, got.Msg)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users?id=1", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHandler(t *testing.T) {
+	rec := new(metrics.Memory)
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{id}", Handler(func(http.ResponseWriter, *http.Request) error {
+		return errs.B().Code(errs.NotFound).Msg("user not found").Err()
+	}, WithRecorder(rec)))
+	mux.Handle("GET /orders/{id}", Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("nil map")
+	}), WithRecorder(rec)))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	assert.Equal(t, []metrics.Observation{
+		{Transport: metrics.HTTP, Code: errs.NotFound, Op: "GET /users/{id}"},
+		{Transport: metrics.HTTP, Code: errs.Internal, Op: "GET /orders/{id}"},
+	}, rec.Observations())
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("panic is written as internal error", func(t *testing.T) {
+		handler := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(errors.New("nil map"))
+		}))
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users", nil)
+		r.Header.Set("Accept", "text/plain")
+
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "internal", w.Body.String(), "the operation of internal errors is not sent")
+	})
+	t.Run("paths are not recorded as operations", func(t *testing.T) {
+		rec := new(metrics.Memory)
+		handler := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("nil map")
+		}), WithRecorder(rec))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+		assert.Equal(t, []metrics.Observation{{Transport: metrics.HTTP, Code: errs.Internal}}, rec.Observations())
+	})
+	t.Run("no panic", func(t *testing.T) {
+		handler := Recover(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+	t.Run("abort handler is not recovered", func(t *testing.T) {
+		handler := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
+
+func TestWriteError_multi(t *testing.T) {
+	err := errs.MultiB().
+		Add(errs.B().Code(errs.InvalidArgument).Op("name").Msg("name is required").Err()).
+		Add(errs.B().Code(errs.InvalidArgument).Op("email").Msg("email is invalid").Err()).
+		ErrOrNil()
+
+	w := httptest.NewRecorder()
+	WriteError(w, httptest.NewRequest(http.MethodPost, "/users", nil), err)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"code":"invalid_argument","errors":[`+
+		`{"op":"name","message":["name is required"],"code":"invalid_argument"},`+
+		`{"op":"email","message":["email is invalid"],"code":"invalid_argument"}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	r.Header.Set("Accept", ProblemContentType)
+	WriteError(w, r, err)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"type":"urn:errs:invalid_argument","title":"invalid argument","status":400,`+
+		`"detail":"name is required; email is invalid"}`, w.Body.String())
+}
+
+func TestWriteError_wrappedMulti(t *testing.T) {
+	m := errs.MultiB().
+		Add(errs.B().Code(errs.InvalidArgument).Op("name").Msg("name is required").Err()).
+		Add(errs.B().Code(errs.InvalidArgument).Op("email").Msg("email is invalid").Err()).
+		ErrOrNil()
+
+	w := httptest.NewRecorder()
+	WriteError(w, httptest.NewRequest(http.MethodPost, "/users", nil), errs.WrapCode(m, errs.Unavailable, "validation service down"))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "the code of the wrapping error is kept")
+	assert.JSONEq(t, `{"op":"","message":["validation service down"],"code":"unavailable"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	WriteError(w, httptest.NewRequest(http.MethodPost, "/users", nil), fmt.Errorf("creating user: %w", m))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "foreign errors wrapping a MultiError are written as the MultiError")
+	assert.Contains(t, w.Body.String(), `"errors":[`)
+
+	p := ToProblem(errs.WrapCode(m, errs.Unavailable, "validation service down"))
+	assert.Equal(t, http.StatusServiceUnavailable, p.Status)
+	assert.Equal(t, "validation service down", p.Detail)
+}
@@ -0,0 +1,339 @@
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+// ProblemContentType is the media type of problem details documents defined by RFC 9457.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypePrefix is the prefix of the type of problems whose code has no type registered
+// with RegisterProblemType and no documentation URL. The string representation of the code is appended to it.
+var ProblemTypePrefix = "urn:errs:"
+
+// Problem is a problem details object as defined by RFC 9457.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type. It is derived from the code of the error.
+	Type string `json:"type,omitempty"`
+
+	// Title is a short summary of the problem type, the description of the code.
+	Title string `json:"title,omitempty"`
+
+	// Status is the HTTP status code mapped to the code.
+	Status int `json:"status,omitempty"`
+
+	// Detail is the explanation of this occurrence of the problem, built from the SHOWN messages of the error.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance identifies this occurrence of the problem, the operation of the error.
+	Instance string `json:"instance,omitempty"`
+
+	// Errors are the field violations of the SHOWN errors, see errs.Builder.Field.
+	Errors []errs.FieldViolation `json:"errors,omitempty"`
+
+	// Extensions are additional members of the problem.
+	// Members with the names of the members above are ignored when encoding.
+	Extensions map[string]any `json:"-"`
+}
+
+type problemMembers Problem
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	byt, err := json.Marshal((*problemMembers)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return byt, err
+	}
+
+	members := make(map[string]any, len(p.Extensions)+6)
+	maps.Copy(members, p.Extensions)
+	if err = json.Unmarshal(byt, &members); err != nil {
+		return nil, err
+	}
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Members that are not standard are decoded into Extensions.
+func (p *Problem) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, (*problemMembers)(p)); err != nil {
+		return err
+	}
+	var members map[string]any
+	if err := json.Unmarshal(b, &members); err != nil {
+		return err
+	}
+	for _, name := range []string{"type", "title", "status", "detail", "instance", "errors"} {
+		delete(members, name)
+	}
+	p.Extensions = nil
+	if len(members) > 0 {
+		p.Extensions = members
+	}
+	return nil
+}
+
+// Err converts the problem to an *errs.Error.
+// The code is resolved from the type, then from the title and lastly from the status of the problem.
+// Errors are added as field violations and extensions are kept in the details of the error.
+func (p *Problem) Err() error {
+	b := errs.B().Code(p.code()).Op(p.Instance).Msg(p.Detail)
+	for _, f := range p.Errors {
+		b.FieldCode(f.Field, f.Code, f.Description)
+	}
+	if len(p.Extensions) > 0 {
+		b.Details(p.Extensions)
+	}
+	return b.Err()
+}
+
+func (p *Problem) code() errs.Code {
+	if c, ok := codeOfType(p.Type); ok {
+		return c
+	}
+	if c, err := errs.ParseCode(p.Title); err == nil && c != errs.Unknown {
+		return c
+	}
+	return codeOfStatus(p.Status)
+}
+
+// ProblemOption configures how errors are converted to problems.
+type ProblemOption func(*problemOptions)
+
+type problemOptions struct {
+	extensions map[string]func(e *errs.Error) any
+	registry   *errs.Registry
+}
+
+// WithProblemRegistry sets the errs.Registry that the type, title, status and public messages of problems are
+// taken from, instead of the default registry.
+func WithProblemRegistry(reg *errs.Registry) ProblemOption {
+	return func(o *problemOptions) {
+		o.registry = reg
+	}
+}
+
+// WithExtension adds the extension member key to problems, with the value returned by fn for the error.
+// The member is omitted when fn returns nil.
+func WithExtension(key string, fn func(e *errs.Error) any) ProblemOption {
+	return func(o *problemOptions) {
+		if o.extensions == nil {
+			o.extensions = make(map[string]func(e *errs.Error) any)
+		}
+		o.extensions[key] = fn
+	}
+}
+
+// ToProblem converts the first *errs.Error wrapped by err to a problem, errors that do not wrap any are converted
+// with errs.Convert. nil errors return nil.
+//
+// An *errs.MultiError that no *errs.Error wraps is converted with its derived code and the details of all its errors,
+// separated by "; ".
+// Extensions are not added to it.
+func ToProblem(err error, opts ...ProblemOption) *Problem {
+	if err == nil {
+		return nil
+	}
+	o := &problemOptions{registry: errs.DefaultRegistry()}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if m, ok := asMulti(err); ok {
+		return multiProblem(o.registry, m)
+	}
+
+	e := asError(err)
+	code := errs.CodeOf(e)
+	spec := o.registry.Spec(code)
+	msgs, fields := shownMessages(o.registry, e)
+	p := &Problem{
+		Type:     problemType(o.registry, code),
+		Title:    spec.Description,
+		Status:   spec.HTTP,
+		Detail:   strings.Join(msgs, errs.Separator),
+		Instance: e.SafeOp(),
+		Errors:   fields,
+	}
+	for key, fn := range o.extensions {
+		v := fn(e)
+		if v == nil {
+			continue
+		}
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]any)
+		}
+		p.Extensions[key] = v
+	}
+	return p
+}
+
+func multiProblem(reg *errs.Registry, m *errs.MultiError) *Problem {
+	code := m.Code()
+	spec := reg.Spec(code)
+	p := &Problem{
+		Type:   problemType(reg, code),
+		Title:  spec.Description,
+		Status: spec.HTTP,
+	}
+	details := make([]string, 0, len(m.Errors()))
+	for _, e := range m.Errors() {
+		msgs, fields := shownMessages(reg, e)
+		details = append(details, strings.Join(msgs, errs.Separator))
+		p.Errors = append(p.Errors, fields...)
+	}
+	p.Detail = strings.Join(details, "; ")
+	return p
+}
+
+// WriteProblem writes err to w as a problem details document.
+// When err is nil, WriteProblem is a no-op.
+func WriteProblem(w http.ResponseWriter, _ *http.Request, err error, opts ...ProblemOption) {
+	p := ToProblem(err, opts...)
+	if p == nil {
+		return
+	}
+	setRetryAfter(w, err)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// FromResponse returns the error described by the body of resp. nil is returned for responses that are not errors.
+//
+// Problem details documents are converted with (*Problem).Err, JSON bodies are decoded as *errs.Error when they
+// have a code or a message, and other bodies are used as the message of an error with the code mapped to the
+// status of resp.
+// The delay of the Retry-After header, if any, is kept as the retry hint of the error, see errs.RetryAfter.
+// FromResponse reads the body of resp but does not close it.
+func FromResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	err := fromResponse(resp)
+	if d, ok := retryAfter(resp); ok {
+		return errs.B(err).RetryAfter(d).Err()
+	}
+	return err
+}
+
+func fromResponse(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errs.WrapCode(err, codeOfStatus(resp.StatusCode), "reading error response")
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case ProblemContentType:
+		p := new(Problem)
+		if json.Unmarshal(body, p) == nil {
+			return p.Err()
+		}
+	case "application/json":
+		// JSON bodies of other services decode without error but have neither code nor message
+		e := new(errs.Error)
+		if json.Unmarshal(body, e) == nil && (e.Code != errs.Unknown || len(e.Msg) > 0) {
+			return e
+		}
+	}
+	return errs.B().Code(codeOfStatus(resp.StatusCode)).Msg(string(body)).Err()
+}
+
+var (
+	problemTypesMu sync.RWMutex
+	problemTypes   = make(map[errs.Code]string)
+)
+
+// RegisterProblemType registers the problem type URI of a code, overriding the documentation URL of the code
+// (see errs.CodeSpec) and the type derived from ProblemTypePrefix.
+// Registering an empty uri removes the registration.
+func RegisterProblemType(c errs.Code, uri string) {
+	problemTypesMu.Lock()
+	defer problemTypesMu.Unlock()
+	if uri == "" {
+		delete(problemTypes, c)
+		return
+	}
+	problemTypes[c] = uri
+}
+
+func problemType(reg *errs.Registry, c errs.Code) string {
+	problemTypesMu.RLock()
+	defer problemTypesMu.RUnlock()
+	if uri, ok := problemTypes[c]; ok {
+		return uri
+	}
+	if uri := reg.Spec(c).DocURL; uri != "" {
+		return uri
+	}
+	return ProblemTypePrefix + reg.String(c)
+}
+
+func codeOfType(uri string) (errs.Code, bool) {
+	if uri == "" {
+		return errs.Unknown, false
+	}
+	problemTypesMu.RLock()
+	defer problemTypesMu.RUnlock()
+	for c, u := range problemTypes {
+		if u == uri {
+			return c, true
+		}
+	}
+	for _, c := range errs.Codes() {
+		if c.DocURL() == uri {
+			return c, true
+		}
+	}
+	name, ok := strings.CutPrefix(uri, ProblemTypePrefix)
+	if !ok {
+		return errs.Unknown, false
+	}
+	c, err := errs.ParseCode(name)
+	return c, err == nil
+}
+
+// codeOfStatus returns the first code that maps to the HTTP status.
+func codeOfStatus(status int) errs.Code {
+	for c := errs.Code(0); c < errs.CodeSize; c++ {
+		if c.HTTP() == status {
+			return c
+		}
+	}
+	return errs.Unknown
+}
+
+// cause returns the underlying *errs.Error of e, or nil.
+// The error e was converted from, if any, wraps it, see (*errs.Error).Unwrap.
+func cause(e *errs.Error) *errs.Error {
+	var c *errs.Error
+	if e.Depth() > 0 && errors.As(e.Unwrap(), &c) {
+		return c
+	}
+	return nil
+}
+
+// shownMessages returns the messages and field violations of e and its SHOWN underlying errors.
+// Codes without messages are described by their public message in reg.
+func shownMessages(reg *errs.Registry, e *errs.Error) (msgs []string, fields []errs.FieldViolation) {
+	for i, er := 0, e; er != nil; i, er = i+1, cause(er) {
+		if i == 0 || er.Shown() {
+			if public := reg.Spec(er.Code).PublicMessage; len(er.Msg) == 0 && public != "" {
+				msgs = append(msgs, public)
+			}
+			msgs = append(msgs, er.Msg...)
+			fields = append(fields, er.Fields...)
+		}
+	}
+	return msgs, fields
+}
@@ -0,0 +1,175 @@
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+func TestToProblem(t *testing.T) {
+	err := errs.Wrap(
+		errs.B().Code(errs.NotFound).Msg("user 42 not found").Details("user_id", 42).Show().Err(),
+		errs.B().Op("Users.Get").Msg("fetching user").Err(),
+	)
+
+	t.Run("standard members", func(t *testing.T) {
+		assert.Equal(t, &Problem{
+			Type:     "urn:errs:not_found",
+			Title:    "not found",
+			Status:   http.StatusNotFound,
+			Detail:   "fetching user: user 42 not found",
+			Instance: "Users.Get",
+		}, ToProblem(err))
+		assert.Nil(t, ToProblem(nil))
+	})
+	t.Run("registered type", func(t *testing.T) {
+		custom := errs.Code(errs.CodeSize + 20)
+		errs.RegisterCode(custom, http.StatusTeapot, codes.Unknown, "teapot")
+		RegisterProblemType(custom, "https://example.com/problems/teapot")
+		defer func() {
+			errs.UnregisterCode(custom)
+			RegisterProblemType(custom, "")
+		}()
+
+		p := ToProblem(errs.B().Code(custom).Err())
+		assert.Equal(t, "https://example.com/problems/teapot", p.Type)
+		assert.Equal(t, "teapot", p.Title)
+		assert.Equal(t, http.StatusTeapot, p.Status)
+		assert.Equal(t, custom, p.Err().(*errs.Error).Code)
+	})
+	t.Run("extensions", func(t *testing.T) {
+		p := ToProblem(err,
+			WithExtension("user_id", func(e *errs.Error) any {
+				var cause *errs.Error
+				if errors.As(e.Unwrap(), &cause) && len(cause.Details) == 2 {
+					return cause.Details[1]
+				}
+				return nil
+			}),
+			WithExtension("omitted", func(*errs.Error) any { return nil }),
+		)
+		byt, e := json.Marshal(p)
+		require.NoError(t, e)
+		assert.JSONEq(t, `{"type":"urn:errs:not_found","title":"not found","status":404,`+
+			`"detail":"fetching user: user 42 not found","instance":"Users.Get","user_id":42}`, string(byt))
+
+		decoded := new(Problem)
+		require.NoError(t, json.Unmarshal(byt, decoded))
+		assert.Equal(t, map[string]any{"user_id": float64(42)}, decoded.Extensions)
+		p.Extensions["user_id"] = float64(42)
+		assert.Equal(t, p, decoded)
+	})
+}
+
+func TestProblem_Err(t *testing.T) {
+	testcases := []struct {
+		name    string
+		problem Problem
+		expect  error
+	}{
+		{
+			name:    "code from type",
+			problem: Problem{Type: "urn:errs:already_exists", Title: "Conflict", Detail: "user exists", Instance: "Users.Create"},
+			expect:  errs.B().Code(errs.AlreadyExists).Op("Users.Create").Msg("user exists").Err(),
+		},
+		{
+			name:    "code from title",
+			problem: Problem{Type: "https://example.com/problems/out-of-credit", Title: "resource_exhausted", Status: 403},
+			expect:  errs.B().Code(errs.ResourceExhausted).Err(),
+		},
+		{
+			name:    "code from status",
+			problem: Problem{Title: "Forbidden", Status: 403, Extensions: map[string]any{"balance": 30.0}},
+			expect:  errs.B().Code(errs.Forbidden).Details(map[string]any{"balance": 30.0}).Err(),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, tc.problem.Err())
+		})
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	err := errs.B().Code(errs.InvalidArgument).Msg("name is required").Err()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	r.Header.Set("Accept", "application/problem+json, application/json;q=0.9")
+	WriteError(w, r, err)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type":"urn:errs:invalid_argument","title":"invalid argument","status":400,"detail":"name is required"}`, w.Body.String())
+
+	assert.True(t, errors.Is(FromResponse(w.Result()), err))
+}
+
+func TestFromResponse(t *testing.T) {
+	response := func(status int, contentType, body string) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{contentType}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	assert.NoError(t, FromResponse(response(http.StatusOK, "application/json", `{}`)))
+	assert.Equal(t,
+		errs.B().Code(errs.NotFound).Op("Users.Get").Msg("user not found").Err(),
+		FromResponse(response(http.StatusNotFound, "application/json", `{"op":"Users.Get","message":["user not found"],"code":"not_found"}`)),
+	)
+	assert.Equal(t,
+		errs.B().Code(errs.NotFound).Msg(`{"error":"user not found"}`).Err(),
+		FromResponse(response(http.StatusNotFound, "application/json", `{"error":"user not found"}`)),
+		"JSON bodies of other services",
+	)
+	assert.Equal(t,
+		errs.B().Code(errs.Unavailable).Msg("upstream is down").Err(),
+		FromResponse(response(http.StatusServiceUnavailable, "text/plain; charset=utf-8", "upstream is down")),
+	)
+
+	limited := response(http.StatusTooManyRequests, "text/plain", "slow down")
+	limited.Header.Set("Retry-After", "30")
+	d, ok := errs.RetryAfter(FromResponse(limited))
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+}
+
+func TestToProblem_fields(t *testing.T) {
+	err := errs.WrapB(errs.B().Field("address.zip", "must have 5 digits").Show().Err()).
+		Msg("invalid user").
+		FieldCode("email", errs.AlreadyExists, "is taken").
+		Err()
+
+	p := ToProblem(err)
+	assert.Equal(t, []errs.FieldViolation{
+		{Field: "email", Code: errs.AlreadyExists, Description: "is taken"},
+		{Field: "address.zip", Code: errs.InvalidArgument, Description: "must have 5 digits"},
+	}, p.Errors)
+
+	byt, e := json.Marshal(p)
+	require.NoError(t, e)
+	assert.JSONEq(t, `{"type":"urn:errs:invalid_argument","title":"invalid argument","status":400,"detail":"invalid user",`+
+		`"errors":[{"field":"email","code":"already_exists","description":"is taken"},`+
+		`{"field":"address.zip","code":"invalid_argument","description":"must have 5 digits"}]}`, string(byt))
+
+	decoded := new(Problem)
+	require.NoError(t, json.Unmarshal(byt, decoded))
+	assert.Nil(t, decoded.Extensions)
+	assert.Equal(t, p.Errors, errs.FieldViolations(decoded.Err()))
+
+	multi := errs.MultiB().Add(err, errs.B().Field("name", "is required").Err()).ErrOrNil()
+	assert.Len(t, ToProblem(multi).Errors, 3)
+}
@@ -0,0 +1,4 @@
+// Package errspb contains the protobuf messages used to send *errs.Error values as gRPC status details.
+package errspb
+
+//go:generate protoc -I proto --go_out=. --go_opt=module=github.com/lordvidex/errs/v2/internal/errspb lordvidex/errs/v1/errs.proto
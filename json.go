@@ -0,0 +1,118 @@
+package errs
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// jsonError is the JSON representation of an *Error node.
+type jsonError struct {
+	Op       string           `json:"op"`
+	Msg      []string         `json:"message"`
+	Internal []string         `json:"internal,omitempty"`
+	Code     Code             `json:"code"`
+	Fields   []FieldViolation `json:"fields,omitempty"`
+	Details  []any            `json:"details,omitempty"`
+	Attrs    map[string]any   `json:"attrs,omitempty"`
+	Hidden   bool             `json:"hidden,omitempty"`
+	Causes   []jsonError      `json:"causes,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Underlying errors are encoded in the "causes" field only if they are SHOWN, the same way Error() prints them.
+// Nodes are encoded with the information returned by SafeError, so internal messages are left out, and so are
+// the operations of errors with redacted codes. Details are never encoded, use Debug for internal endpoints that need them.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON(false))
+}
+
+// Debug returns a json.Marshaler that encodes err with the internal messages, details, attributes and ALL underlying
+// errors, including the ones that are not shown. Hidden errors are marked with the "hidden" field.
+//
+// The output exposes internal information and should only be used for internal and debug endpoints.
+func Debug(err error) json.Marshaler {
+	return debugError{convert(err)}
+}
+
+type debugError struct {
+	err *Error
+}
+
+func (d debugError) MarshalJSON() ([]byte, error) {
+	if d.err == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.err.toJSON(true))
+}
+
+func (e *Error) toJSON(debug bool) jsonError {
+	v := e.jsonNode(debug)
+	if !debug {
+		for er := range shown(e.cause) {
+			v.Causes = append(v.Causes, er.jsonNode(false))
+		}
+		return v
+	}
+
+	for _, er := range all(e.cause) {
+		node := er.jsonNode(true)
+		node.Hidden = !er.show
+		v.Causes = append(v.Causes, node)
+	}
+	return v
+}
+
+func (e *Error) jsonNode(debug bool) jsonError {
+	if !debug {
+		v := jsonError{Op: e.SafeOp(), Msg: e.publicMsg(), Code: e.Code, Fields: e.Fields}
+		if full() {
+			v.Internal = e.InternalMsg
+		}
+		return v
+	}
+	v := jsonError{Op: e.Op, Msg: e.Msg, Internal: e.InternalMsg, Code: e.Code, Fields: e.Fields}
+	for _, d := range e.Details {
+		// most errors have no exported fields and would be encoded as {}
+		if err, ok := d.(error); ok {
+			d = err.Error()
+		}
+		v.Details = append(v.Details, d)
+	}
+	v.Attrs = attrMap(e.Attrs)
+	return v
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Errors listed in the "causes" field are decoded as underlying errors, outermost first. They are SHOWN unless
+// they are marked with the "hidden" field.
+func (e *Error) UnmarshalJSON(b []byte) error {
+	var v jsonError
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	var cause *Error
+	for i := len(v.Causes) - 1; i >= 0; i-- {
+		inner := v.Causes[i].node()
+		inner.show = !v.Causes[i].Hidden
+		if inner.show {
+			inner.shownDepth = 1
+		}
+		inner.wrap(cause)
+		cause = inner
+	}
+
+	*e = *v.node()
+	e.wrap(cause)
+	return nil
+}
+
+func (v jsonError) node() *Error {
+	e := &Error{Op: v.Op, Msg: v.Msg, InternalMsg: v.Internal, Code: v.Code, Fields: v.Fields, Details: v.Details}
+	for _, k := range slices.Sorted(maps.Keys(v.Attrs)) {
+		e.Attrs = append(e.Attrs, slog.Any(k, v.Attrs[k]))
+	}
+	return e
+}
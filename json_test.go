@@ -0,0 +1,121 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCode_UnmarshalJSON(t *testing.T) {
+	t.Run("default names", func(t *testing.T) {
+		for i := 0; i < CodeSize; i++ {
+			byt, err := Code(i).MarshalJSON()
+			require.NoError(t, err)
+
+			var c Code
+			require.NoError(t, json.Unmarshal(byt, &c))
+			assert.Equal(t, Code(i), c)
+		}
+	})
+	t.Run("registered codes", func(t *testing.T) {
+		custom := Code(CodeSize + 10)
+		RegisterCode(custom, 418, codes.Unknown, "teapot")
+		defer UnregisterCode(custom)
+
+		byt, err := custom.MarshalJSON()
+		require.NoError(t, err)
+		assert.Equal(t, `"teapot"`, string(byt))
+
+		var c Code
+		require.NoError(t, json.Unmarshal(byt, &c))
+		assert.Equal(t, custom, c)
+	})
+	t.Run("unknown name", func(t *testing.T) {
+		c := NotFound
+		require.NoError(t, json.Unmarshal([]byte(`"no_such_code"`), &c))
+		assert.Equal(t, Unknown, c)
+	})
+	t.Run("not a string", func(t *testing.T) {
+		var c Code
+		assert.Error(t, json.Unmarshal([]byte(`5`), &c))
+	})
+}
+
+func TestError_UnmarshalJSON(t *testing.T) {
+	t.Run("round trip keeps errors.Is working", func(t *testing.T) {
+		sentinel := B().Code(NotFound).Op("UserRepository.Get").Msg("user not found").Err()
+		byt, err := json.Marshal(sentinel)
+		require.NoError(t, err)
+
+		decoded := new(Error)
+		require.NoError(t, json.Unmarshal(byt, decoded))
+		assert.ErrorIs(t, decoded, sentinel)
+		assert.Equal(t, sentinel.Error(), decoded.Error())
+	})
+	t.Run("shown causes", func(t *testing.T) {
+		body := `{"op":"FetchItem","message":["item not found"],"code":"not_found","causes":[` +
+			`{"op":"Cache","message":["cache miss"],"code":"internal"},` +
+			`{"op":"Postgres","message":["connection failed"],"code":"aborted"}]}`
+
+		decoded := new(Error)
+		require.NoError(t, json.Unmarshal([]byte(body), decoded))
+		assert.Equal(t, "not_found: FetchItem: item not found\n"+
+			"internal: Cache: cache miss\n"+
+			"aborted: Postgres: connection failed", decoded.Error())
+		assert.Equal(t, 2, decoded.depth)
+		assert.Equal(t, 2, decoded.shownDepth)
+		assert.ErrorIs(t, decoded, B().Code(Aborted).Op("Postgres").Msg("connection failed").Err())
+		assert.False(t, errors.Is(decoded, B().Code(Aborted).Msg("connection failed").Err()))
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		assert.Error(t, json.Unmarshal([]byte(`{"code":1}`), new(Error)))
+	})
+}
+
+func TestError_MarshalJSON(t *testing.T) {
+	err := Wrap(
+		Wrap(
+			B().Code(Aborted).Op("Postgres").Msg("connection failed").Details("dial tcp: timeout").Show().Err(),
+			B().Code(Internal).Op("Cache").Msg("cache miss").Err(),
+		),
+		B().Code(NotFound).Op("FetchItem").Msg("item not found").Details("id", 42).Err(),
+	)
+
+	t.Run("only shown causes", func(t *testing.T) {
+		byt, e := json.Marshal(err)
+		require.NoError(t, e)
+		assert.JSONEq(t, `{"op":"FetchItem","message":["item not found"],"code":"not_found","causes":[`+
+			`{"op":"Postgres","message":["connection failed"],"code":"aborted"}]}`, string(byt))
+
+		decoded := new(Error)
+		require.NoError(t, json.Unmarshal(byt, decoded))
+		assert.Equal(t, err.Error(), decoded.Error())
+	})
+	t.Run("debug", func(t *testing.T) {
+		byt, e := json.Marshal(Debug(err))
+		require.NoError(t, e)
+		assert.JSONEq(t, `{"op":"FetchItem","message":["item not found"],"code":"not_found","details":["id",42],"causes":[`+
+			`{"op":"Cache","message":["cache miss"],"code":"internal","hidden":true},`+
+			`{"op":"Postgres","message":["connection failed"],"code":"aborted","details":["dial tcp: timeout"]}]}`, string(byt))
+
+		decoded := new(Error)
+		require.NoError(t, json.Unmarshal(byt, decoded))
+		assert.Equal(t, err.Error(), decoded.Error())
+		assert.Equal(t, err.(*Error).depth, decoded.depth)
+		assert.Equal(t, err.(*Error).shownDepth, decoded.shownDepth)
+	})
+	t.Run("debug nil", func(t *testing.T) {
+		byt, e := json.Marshal(Debug(nil))
+		require.NoError(t, e)
+		assert.Equal(t, "null", string(byt))
+	})
+	t.Run("debug error details", func(t *testing.T) {
+		byt, e := json.Marshal(Debug(B().Details(errors.New("boom")).Err()))
+		require.NoError(t, e)
+		assert.JSONEq(t, `{"op":"","message":null,"code":"unknown","details":["boom"]}`, string(byt))
+	})
+}
@@ -0,0 +1,61 @@
+package errs
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements the slog.LogValuer interface. The error is logged as a group with its code, operation,
+// messages, internal messages, details, attributes and the HTTP and gRPC codes mapped to its code.
+//
+// ALL underlying errors are logged as nested "cause" groups, the ones that are not shown are marked with
+// the "hidden" attribute.
+func (e *Error) LogValue() slog.Value {
+	if e == nil {
+		return slog.Value{}
+	}
+	return e.logValue(false)
+}
+
+func (e *Error) logValue(hidden bool) slog.Value {
+	attrs := []slog.Attr{slog.String("code", e.Code.String())}
+	if e.Op != "" {
+		attrs = append(attrs, slog.String("op", e.Op))
+	}
+	if len(e.Msg) > 0 {
+		attrs = append(attrs, slog.Any("message", e.Msg))
+	}
+	if len(e.InternalMsg) > 0 {
+		attrs = append(attrs, slog.Any("internal", e.InternalMsg))
+	}
+	if len(e.Details) > 0 {
+		attrs = append(attrs, slog.Any("details", e.Details))
+	}
+	if len(e.Attrs) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "attrs", Value: slog.GroupValue(e.Attrs...)})
+	}
+	attrs = append(attrs,
+		slog.Int("http", e.Code.HTTP()),
+		slog.String("grpc", e.Code.GRPC().String()),
+	)
+	if hidden {
+		attrs = append(attrs, slog.Bool("hidden", true))
+	}
+	if e.cause != nil {
+		attrs = append(attrs, slog.Attr{Key: "cause", Value: e.cause.logValue(!e.cause.show)})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements the slog.LogValuer interface. The error is logged as a group with the derived code and
+// the contained errors in the "errors" group, keyed by their index.
+func (m *MultiError) LogValue() slog.Value {
+	errs := make([]slog.Attr, 0, len(m.errs))
+	for i, e := range m.errs {
+		errs = append(errs, slog.Attr{Key: strconv.Itoa(i), Value: e.LogValue()})
+	}
+	return slog.GroupValue(
+		slog.String("code", m.Code().String()),
+		slog.Attr{Key: "errors", Value: slog.GroupValue(errs...)},
+	)
+}
@@ -0,0 +1,63 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logJSON logs v with a slog.JSONHandler and returns the decoded "err" attribute.
+func logJSON(t *testing.T, v any) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", "err", v)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return record["err"].(map[string]any)
+}
+
+func TestError_LogValue(t *testing.T) {
+	inner := B().Code(NotFound).Op("Users.Get").Msg("user not found").With("user_id", 42).Err()
+	err := WrapB(inner).Code(Internal).Msg("could not load profile").InternalMsg("cache miss").Details("retried").Err()
+
+	assert.Equal(t, map[string]any{
+		"code":     "internal",
+		"message":  []any{"could not load profile"},
+		"internal": []any{"cache miss"},
+		"details":  []any{"retried"},
+		"http":     float64(500),
+		"grpc":     "Internal",
+		"cause": map[string]any{
+			"code":    "not_found",
+			"op":      "Users.Get",
+			"message": []any{"user not found"},
+			"attrs":   map[string]any{"user_id": float64(42)},
+			"http":    float64(404),
+			"grpc":    "NotFound",
+			"hidden":  true,
+		},
+	}, logJSON(t, err))
+}
+
+func TestMultiError_LogValue(t *testing.T) {
+	err := MultiB().
+		Add(B().Code(InvalidArgument).Op("name").Msg("is required").Err()).
+		Add(B().Code(Internal).Err()).
+		ErrOrNil()
+
+	assert.Equal(t, map[string]any{
+		"code": "internal",
+		"errors": map[string]any{
+			"0": map[string]any{
+				"code": "invalid_argument", "op": "name", "message": []any{"is required"},
+				"http": float64(400), "grpc": "InvalidArgument",
+			},
+			"1": map[string]any{"code": "internal", "http": float64(500), "grpc": "Internal"},
+		},
+	}, logJSON(t, err))
+}
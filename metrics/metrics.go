@@ -0,0 +1,87 @@
+// Package metrics counts errors sent to clients by code, operation and transport.
+//
+// The httperr and status packages record the errors they send with the Recorder set by their WithRecorder options.
+// The prom package provides a Recorder that exports the counts as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+// Transport is the transport an error was sent with.
+type Transport string
+
+const (
+	HTTP Transport = "http"
+	GRPC Transport = "grpc"
+)
+
+// Observation describes an error sent to a client.
+type Observation struct {
+	Transport Transport
+	// Code is the code returned by errs.CodeOf for the error.
+	Code errs.Code
+	// Op is the first operation found in the error chain.
+	Op string
+	// Depth is the number of underlying errors of the first *errs.Error of the error, see (*errs.Error).Depth.
+	Depth int
+}
+
+// Observe returns the Observation of err sent with transport.
+func Observe(transport Transport, err error) Observation {
+	o := Observation{Transport: transport, Code: errs.CodeOf(err)}
+	var e *errs.Error
+	if errors.As(err, &e) {
+		o.Depth = e.Depth()
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		if e, ok := err.(*errs.Error); ok && e.Op != "" {
+			o.Op = e.Op
+			break
+		}
+	}
+	return o
+}
+
+// Recorder records errors sent to clients.
+type Recorder interface {
+	Record(ctx context.Context, o Observation)
+}
+
+// Memory is a Recorder that keeps observations in memory, it is meant for tests.
+// The zero value is ready to use and it is safe for concurrent use.
+type Memory struct {
+	mu           sync.Mutex
+	observations []Observation
+}
+
+// Record implements the Recorder interface.
+func (m *Memory) Record(_ context.Context, o Observation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observations = append(m.observations, o)
+}
+
+// Observations returns the recorded observations in the order they were recorded.
+func (m *Memory) Observations() []Observation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Observation(nil), m.observations...)
+}
+
+// Count returns the number of recorded errors with the transport, code and operation.
+func (m *Memory) Count(transport Transport, code errs.Code, op string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, o := range m.observations {
+		if o.Transport == transport && o.Code == code && o.Op == op {
+			n++
+		}
+	}
+	return n
+}
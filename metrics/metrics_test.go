@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+func TestObserve(t *testing.T) {
+	inner := errs.B().Code(errs.Unavailable).Op("DB.Query").Err()
+	testcases := []struct {
+		name   string
+		err    error
+		expect Observation
+	}{
+		{"foreign error", io.EOF, Observation{Transport: HTTP, Code: errs.Unknown}},
+		{"errs error", inner, Observation{Transport: HTTP, Code: errs.Unavailable, Op: "DB.Query"}},
+		{
+			name:   "first operation of the chain",
+			err:    fmt.Errorf("handler: %w", errs.WrapCode(errs.WrapCode(inner, errs.Internal), errs.Unknown)),
+			expect: Observation{Transport: HTTP, Code: errs.Internal, Op: "DB.Query", Depth: 2},
+		},
+		{
+			name:   "multi error",
+			err:    errs.MultiB().Add(errs.B().Code(errs.InvalidArgument).Op("name").Err()).ErrOrNil(),
+			expect: Observation{Transport: HTTP, Code: errs.InvalidArgument},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, Observe(HTTP, tc.err))
+		})
+	}
+}
+
+func TestMemory(t *testing.T) {
+	var (
+		m  Memory
+		wg sync.WaitGroup
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Record(context.Background(), Observation{Transport: GRPC, Code: errs.Internal, Op: "Users.Get"})
+		}()
+	}
+	wg.Wait()
+	m.Record(context.Background(), Observation{Transport: HTTP, Code: errs.Internal, Op: "Users.Get"})
+
+	assert.Len(t, m.Observations(), 11)
+	assert.Equal(t, 10, m.Count(GRPC, errs.Internal, "Users.Get"))
+	assert.Equal(t, 1, m.Count(HTTP, errs.Internal, "Users.Get"))
+	assert.Zero(t, m.Count(GRPC, errs.NotFound, "Users.Get"))
+}
@@ -0,0 +1,101 @@
+// Package prom exports the errors recorded by the metrics package as Prometheus metrics.
+//
+// It is a separate module, github.com/lordvidex/errs/v2/metrics/prom, so that the errs module does not depend on Prometheus.
+package prom
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lordvidex/errs/v2/metrics"
+)
+
+// Option configures a Collector.
+type Option func(*options)
+
+type options struct {
+	namespace string
+	buckets   []float64
+}
+
+// WithNamespace sets the namespace of the metrics, "errs" by default.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithDepthHistogram enables the errors_depth histogram of the number of underlying errors, with the buckets.
+// prometheus.LinearBuckets(0, 1, 5) is used when no buckets are given.
+func WithDepthHistogram(buckets ...float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+		if len(buckets) == 0 {
+			o.buckets = prometheus.LinearBuckets(0, 1, 5)
+		}
+	}
+}
+
+// Collector is a metrics.Recorder and a prometheus.Collector. It exports:
+//
+// - errors_total, a counter of errors with the code, op and transport labels.
+//
+// - errors_depth, a histogram of the number of underlying errors with the code and transport labels,
+// if enabled with WithDepthHistogram.
+//
+// Operations are used as label values, so they should not contain request specific values such as identifiers.
+type Collector struct {
+	errors *prometheus.CounterVec
+	depth  *prometheus.HistogramVec
+}
+
+// NewCollector returns a Collector, it must be registered to a prometheus.Registerer to be scraped.
+func NewCollector(opts ...Option) *Collector {
+	o := &options{namespace: "errs"}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	c := &Collector{
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "errors_total",
+			Help:      "Number of errors sent to clients.",
+		}, []string{"code", "op", "transport"}),
+	}
+	if o.buckets != nil {
+		c.depth = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: o.namespace,
+			Name:      "errors_depth",
+			Help:      "Number of underlying errors of errors sent to clients.",
+			Buckets:   o.buckets,
+		}, []string{"code", "transport"})
+	}
+	return c
+}
+
+// Record implements the metrics.Recorder interface.
+func (c *Collector) Record(_ context.Context, o metrics.Observation) {
+	code := o.Code.String()
+	c.errors.WithLabelValues(code, o.Op, string(o.Transport)).Inc()
+	if c.depth != nil {
+		c.depth.WithLabelValues(code, string(o.Transport)).Observe(float64(o.Depth))
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.errors.Describe(ch)
+	if c.depth != nil {
+		c.depth.Describe(ch)
+	}
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.errors.Collect(ch)
+	if c.depth != nil {
+		c.depth.Collect(ch)
+	}
+}
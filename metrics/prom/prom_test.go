@@ -0,0 +1,65 @@
+package prom
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lordvidex/errs/v2"
+	"github.com/lordvidex/errs/v2/metrics"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector(WithDepthHistogram(0, 1, 2))
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+
+	ctx := context.Background()
+	c.Record(ctx, metrics.Observation{Transport: metrics.GRPC, Code: errs.Internal, Op: "Users.Get", Depth: 1})
+	c.Record(ctx, metrics.Observation{Transport: metrics.GRPC, Code: errs.Internal, Op: "Users.Get", Depth: 2})
+	c.Record(ctx, metrics.Observation{Transport: metrics.HTTP, Code: errs.NotFound, Op: "GET /users/{id}"})
+
+	expected := `
+# HELP errs_errors_total Number of errors sent to clients.
+# TYPE errs_errors_total counter
+errs_errors_total{code="internal",op="Users.Get",transport="grpc"} 2
+errs_errors_total{code="not_found",op="GET /users/{id}",transport="http"} 1
+# HELP errs_errors_depth Number of underlying errors of errors sent to clients.
+# TYPE errs_errors_depth histogram
+errs_errors_depth_bucket{code="internal",transport="grpc",le="0"} 0
+errs_errors_depth_bucket{code="internal",transport="grpc",le="1"} 1
+errs_errors_depth_bucket{code="internal",transport="grpc",le="2"} 2
+errs_errors_depth_bucket{code="internal",transport="grpc",le="+Inf"} 2
+errs_errors_depth_sum{code="internal",transport="grpc"} 3
+errs_errors_depth_count{code="internal",transport="grpc"} 2
+errs_errors_depth_bucket{code="not_found",transport="http",le="0"} 1
+errs_errors_depth_bucket{code="not_found",transport="http",le="1"} 1
+errs_errors_depth_bucket{code="not_found",transport="http",le="2"} 1
+errs_errors_depth_bucket{code="not_found",transport="http",le="+Inf"} 1
+errs_errors_depth_sum{code="not_found",transport="http"} 0
+errs_errors_depth_count{code="not_found",transport="http"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+}
+
+func TestCollector_Options(t *testing.T) {
+	c := NewCollector(WithNamespace("app"))
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+
+	c.Record(context.Background(), metrics.Observation{Transport: metrics.HTTP, Code: errs.Unavailable})
+
+	count, err := testutil.GatherAndCount(reg)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "no histogram by default")
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.errors.WithLabelValues("unavailable", "", "http")))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	assert.Equal(t, "app_errors_total", families[0].GetName())
+}
@@ -0,0 +1,197 @@
+package errs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// CodePolicy derives the code of a MultiError from the known codes of its errors, in order.
+type CodePolicy func(codes []Code) Code
+
+// MostSevere is the default CodePolicy. It returns the first code with the highest severity, see Code.Severity.
+// By default, codes mapped to 5xx HTTP statuses are more severe than codes mapped to 4xx HTTP statuses.
+func MostSevere(codes []Code) Code {
+	result, max := Unknown, Severity(0)
+	for _, c := range codes {
+		if s := c.Severity(); s > max {
+			result, max = c, s
+		}
+	}
+	return result
+}
+
+// FirstCode is a CodePolicy that returns the first code that is not Unknown.
+func FirstCode(codes []Code) Code {
+	for _, c := range codes {
+		if c != Unknown {
+			return c
+		}
+	}
+	return Unknown
+}
+
+// MultiError is an error that contains several *Error values, for example all failed validations of a request.
+//
+// It is compatible with errors.Join: errors.Is and errors.As match every contained error.
+type MultiError struct {
+	errs   []*Error
+	policy CodePolicy
+}
+
+// Errors returns the contained errors.
+func (m *MultiError) Errors() []*Error {
+	return m.errs
+}
+
+// Code returns the code derived from the contained errors by the CodePolicy, MostSevere by default.
+func (m *MultiError) Code() Code {
+	codes := make([]Code, 0, len(m.errs))
+	for _, e := range m.errs {
+		codes = append(codes, e.knownCode())
+	}
+	if m.policy == nil {
+		return MostSevere(codes)
+	}
+	return m.policy(codes)
+}
+
+// Error returns the contained errors separated by new lines.
+func (m *MultiError) Error() string {
+	msgs := make([]string, 0, len(m.errs))
+	for _, e := range m.errs {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// SafeError returns the contained errors separated by new lines, without the information that must not be
+// sent to clients. See (*Error).SafeError.
+func (m *MultiError) SafeError() string {
+	msgs := make([]string, 0, len(m.errs))
+	for _, e := range m.errs {
+		msgs = append(msgs, e.SafeError())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Stack returns the descriptions of all contained errors. See (*Error).Stack.
+func (m *MultiError) Stack() string {
+	var buf strings.Builder
+	for _, e := range m.errs {
+		buf.WriteString(e.Stack())
+	}
+	return buf.String()
+}
+
+// Format implements the fmt.Formatter interface. The %+v verb prints the description returned by Stack,
+// other verbs print the error returned by Error.
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		io.WriteString(s, m.Stack())
+		return
+	}
+	io.WriteString(s, m.Error())
+}
+
+// Unwrap returns the contained errors.
+func (m *MultiError) Unwrap() []error {
+	result := make([]error, 0, len(m.errs))
+	for _, e := range m.errs {
+		result = append(result, e)
+	}
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The derived code is encoded in the "code" field and the contained errors in the "errors" field.
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code   Code     `json:"code"`
+		Errors []*Error `json:"errors"`
+	}{m.Code(), m.errs})
+}
+
+// GRPCStatus returns a *status.Status with the derived code, an errdetails.BadRequest and, if any of the
+// contained errors has a retry hint, an errdetails.RetryInfo.
+// The field violations of the contained errors are sent as they are, errors without field violations
+// are sent as a violation with the operation as field.
+func (m *MultiError) GRPCStatus() *status.Status {
+	st := status.New(m.Code().GRPC(), m.SafeError())
+
+	var fields []FieldViolation
+	for _, e := range m.errs {
+		if f := e.shownFields(); len(f) > 0 {
+			fields = append(fields, f...)
+			continue
+		}
+		fields = append(fields, FieldViolation{
+			Field:       e.SafeOp(),
+			Code:        e.knownCode(),
+			Description: strings.Join(cleanStrings(e.safeMsg()), Separator),
+		})
+	}
+	details := []protoadapt.MessageV1{badRequest(fields)}
+	if ri := retryInfo(m); ri != nil {
+		details = append(details, ri)
+	}
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// MultiB returns a new builder for a MultiError.
+func MultiB() *MultiBuilder {
+	return new(MultiBuilder)
+}
+
+// MultiBuilder is used to accumulate errors into a MultiError.
+type MultiBuilder struct {
+	m MultiError
+}
+
+// Add adds errors to the builder, nil errors are ignored.
+// Errors that are not *Error are converted with Convert, except for errors that wrap several errors,
+// such as MultiError or the result of errors.Join, whose errors are added one by one.
+func (b *MultiBuilder) Add(errs ...error) *MultiBuilder {
+	for _, err := range errs {
+		switch x := err.(type) {
+		case nil:
+		case *Error:
+			b.m.errs = append(b.m.errs, x)
+		case interface{ Unwrap() []error }:
+			b.Add(x.Unwrap()...)
+		default:
+			b.m.errs = append(b.m.errs, convert(err))
+		}
+	}
+	return b
+}
+
+// Policy sets the CodePolicy used to derive the code of the MultiError.
+func (b *MultiBuilder) Policy(p CodePolicy) *MultiBuilder {
+	b.m.policy = p
+	return b
+}
+
+// Len returns the number of errors added to the builder.
+func (b *MultiBuilder) Len() int {
+	return len(b.m.errs)
+}
+
+// ErrOrNil returns a new *MultiError with the added errors, or nil if no error was added.
+func (b *MultiBuilder) ErrOrNil() error {
+	if len(b.m.errs) == 0 {
+		return nil
+	}
+	return &MultiError{
+		errs:   append([]*Error(nil), b.m.errs...),
+		policy: b.m.policy,
+	}
+}
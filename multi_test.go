@@ -0,0 +1,128 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCodePolicy(t *testing.T) {
+	testcases := []struct {
+		name       string
+		codes      []Code
+		mostSevere Code
+		first      Code
+	}{
+		{"no codes", nil, Unknown, Unknown},
+		{"client errors", []Code{InvalidArgument, NotFound}, InvalidArgument, InvalidArgument},
+		{"server error wins", []Code{InvalidArgument, Unavailable, Internal}, Unavailable, InvalidArgument},
+		{"unknown codes", []Code{Unknown, NotFound}, Unknown, NotFound},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.mostSevere, MostSevere(tc.codes))
+			assert.Equal(t, tc.first, FirstCode(tc.codes))
+		})
+	}
+}
+
+func TestMultiBuilder(t *testing.T) {
+	t.Run("no errors", func(t *testing.T) {
+		b := MultiB().Add(nil, nil)
+		assert.Equal(t, 0, b.Len())
+		assert.NoError(t, b.ErrOrNil())
+	})
+	t.Run("errors are flattened", func(t *testing.T) {
+		first := B().Code(InvalidArgument).Op("name").Msg("name is required").Err()
+		second := B().Code(InvalidArgument).Op("email").Msg("email is invalid").Err()
+		nested := MultiB().Add(second).ErrOrNil()
+
+		err := MultiB().Add(first, nil).Add(errors.Join(nested, io.EOF)).ErrOrNil()
+		require.IsType(t, &MultiError{}, err)
+
+		m := err.(*MultiError)
+		require.Len(t, m.Errors(), 3)
+		assert.Equal(t, first, m.Errors()[0])
+		assert.Equal(t, second, m.Errors()[1])
+		assert.Equal(t, Convert(io.EOF), m.Errors()[2])
+
+		assert.ErrorIs(t, err, first)
+		assert.ErrorIs(t, err, second)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+	t.Run("policy", func(t *testing.T) {
+		b := MultiB().Add(B().Code(NotFound).Err(), B().Code(Internal).Err())
+		assert.Equal(t, Internal, b.ErrOrNil().(*MultiError).Code())
+		assert.Equal(t, NotFound, b.Policy(FirstCode).ErrOrNil().(*MultiError).Code())
+	})
+}
+
+func TestMultiError(t *testing.T) {
+	err := MultiB().
+		Add(B().Code(InvalidArgument).Op("name").Msg("name is required").Err()).
+		Add(B().Code(InvalidArgument).Op("email").Msg("email is invalid").Details("a@").Err()).
+		ErrOrNil().(*MultiError)
+
+	t.Run("error", func(t *testing.T) {
+		assert.Equal(t, "invalid_argument: name: name is required\ninvalid_argument: email: email is invalid", err.Error())
+		assert.Equal(t, err.Error(), fmt.Sprintf("%v", err))
+	})
+	t.Run("stack", func(t *testing.T) {
+		assert.Equal(t, "invalid_argument: name: name is required\n\n"+
+			"invalid_argument: email: email is invalid\n\t0: a@\n\n", err.Stack())
+		assert.Equal(t, err.Stack(), fmt.Sprintf("%+v", err))
+	})
+	t.Run("json", func(t *testing.T) {
+		byt, e := json.Marshal(err)
+		require.NoError(t, e)
+		assert.JSONEq(t, `{"code":"invalid_argument","errors":[`+
+			`{"op":"name","message":["name is required"],"code":"invalid_argument"},`+
+			`{"op":"email","message":["email is invalid"],"code":"invalid_argument"}]}`, string(byt))
+	})
+	t.Run("grpc", func(t *testing.T) {
+		st := err.GRPCStatus()
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, err.Error(), st.Message())
+		require.Len(t, st.Details(), 1)
+		br := st.Details()[0].(*errdetails.BadRequest)
+		require.Len(t, br.GetFieldViolations(), 2)
+		assert.Equal(t, "email", br.GetFieldViolations()[1].GetField())
+		assert.Equal(t, "email is invalid", br.GetFieldViolations()[1].GetDescription())
+	})
+}
+
+func TestWrap_multi(t *testing.T) {
+	invalid := B().Code(InvalidArgument).Field("name", "is required").Err()
+	internal := B().Code(Internal).Msg("database is down").Err()
+
+	t.Run("MultiError", func(t *testing.T) {
+		m := MultiB().Add(invalid, internal).ErrOrNil()
+		for _, err := range []error{WrapMsg(m, "validating user"), WrapCode(m, Unknown), Wrap(m, B().Msg("ctx").Err()), B(m).Err(), WrapB(m).Err()} {
+			assert.Equal(t, Internal, err.(*Error).Code, "the derived code of the MultiError is kept")
+			assert.ErrorIs(t, err, invalid)
+			assert.ErrorIs(t, err, internal)
+
+			var got *MultiError
+			require.ErrorAs(t, err, &got)
+			assert.Same(t, m, got)
+		}
+		assert.Equal(t, "internal: validating user", WrapMsg(m, "validating user").Error())
+	})
+	t.Run("errors.Join", func(t *testing.T) {
+		joined := errors.Join(io.EOF, invalid, internal)
+		err := WrapMsg(joined, "validating user")
+		assert.Equal(t, InvalidArgument, err.(*Error).Code, "the code is returned by CodeOf")
+		assert.ErrorIs(t, err, io.EOF)
+		assert.ErrorIs(t, err, invalid)
+		assert.ErrorIs(t, err, internal)
+		assert.ErrorIs(t, err, joined)
+		assert.Len(t, FieldViolations(err), 1)
+	})
+}
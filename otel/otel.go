@@ -0,0 +1,97 @@
+// Package otel records errs errors on OpenTelemetry spans.
+//
+// It is a separate module, github.com/lordvidex/errs/v2/otel, so that the errs module does not depend on OpenTelemetry.
+package otel
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+// Attribute keys set by RecordError in addition to the semantic conventions.
+const (
+	// CodeKey is the name of the code of the error.
+	CodeKey = attribute.Key("errs.code")
+	// OpKey is the operation of the first *errs.Error of the error.
+	OpKey = attribute.Key("errs.op")
+	// MessagesKey are the messages of all *errs.Error in the error, outermost first, see (*errs.Error).String.
+	MessagesKey = attribute.Key("errs.messages")
+)
+
+// RecordError records err on span. Errors that are not *errs.Error are recorded with the code returned by errs.CodeOf.
+// It does nothing if err is nil or span is not recording.
+//
+// - the span gets the error.type, rpc.grpc.status_code and http.response.status_code attributes, from the
+// name of the code and the codes returned by Code.GRPC and Code.HTTP.
+//
+// - an exception event is added with the code, the operation, the messages of all errors and the description
+// returned by Stack as exception.stacktrace.
+//
+// - the status of the span is set to Error only for server-side errors, whose code is mapped to the Internal, Unknown,
+// DataLoss or Unavailable gRPC codes. Other errors are caused by clients and do not fail the span.
+func RecordError(span trace.Span, err error) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	code := errs.CodeOf(err)
+	span.SetAttributes(
+		semconv.ErrorTypeKey.String(code.String()),
+		semconv.RPCGRPCStatusCodeKey.Int(int(code.GRPC())),
+		semconv.HTTPResponseStatusCode(code.HTTP()),
+	)
+
+	attrs := []attribute.KeyValue{CodeKey.String(code.String())}
+	var e *errs.Error
+	if errors.As(err, &e) && e.Op != "" {
+		attrs = append(attrs, OpKey.String(e.Op))
+	}
+	if msgs := messages(err); len(msgs) > 0 {
+		attrs = append(attrs, MessagesKey.StringSlice(msgs))
+	}
+	if s, ok := err.(interface{ Stack() string }); ok {
+		attrs = append(attrs, semconv.ExceptionStacktrace(s.Stack()))
+	} else if e != nil {
+		attrs = append(attrs, semconv.ExceptionStacktrace(e.Stack()))
+	}
+	span.RecordError(err, trace.WithAttributes(attrs...))
+
+	if serverError(code) {
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+}
+
+// serverError reports whether errors with the code are caused by the server.
+func serverError(c errs.Code) bool {
+	switch c.GRPC() {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// messages returns the messages of all *errs.Error in the tree of err, walking it like errs.CodeOf.
+func messages(err error) []string {
+	var result []string
+	for err != nil {
+		switch x := err.(type) {
+		case *errs.Error:
+			result = append(result, x.String())
+		case interface{ Unwrap() []error }:
+			for _, inner := range x.Unwrap() {
+				result = append(result, messages(inner)...)
+			}
+			return result
+		}
+		err = errors.Unwrap(err)
+	}
+	return result
+}
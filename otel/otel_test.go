@@ -0,0 +1,98 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+// record records err on a new span and returns the ended span.
+func record(t *testing.T, err error) sdktrace.ReadOnlySpan {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	_, span := provider.Tracer("test").Start(context.Background(), "op")
+	RecordError(span, err)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	return spans[0]
+}
+
+func attrs(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestRecordError(t *testing.T) {
+	inner := errs.B().Code(errs.Unavailable).Op("DB.Query").Msg("database is down").Show().Err()
+	span := record(t, fmt.Errorf("handler: %w", errs.WrapCode(inner, errs.Internal, "could not load user")))
+
+	assert.Equal(t, otelcodes.Error, span.Status().Code)
+	assert.Equal(t, "handler: internal: could not load user\nunavailable: DB.Query: database is down", span.Status().Description)
+
+	spanAttrs := attrs(span.Attributes())
+	assert.Equal(t, "internal", spanAttrs["error.type"].AsString())
+	assert.Equal(t, int64(13), spanAttrs["rpc.grpc.status_code"].AsInt64())
+	assert.Equal(t, int64(500), spanAttrs["http.response.status_code"].AsInt64())
+
+	require.Len(t, span.Events(), 1)
+	event := span.Events()[0]
+	assert.Equal(t, "exception", event.Name)
+	eventAttrs := attrs(event.Attributes)
+	assert.Equal(t, "internal", eventAttrs[CodeKey].AsString())
+	assert.Equal(t, []string{"internal: could not load user", "unavailable: DB.Query: database is down"},
+		eventAttrs[MessagesKey].AsStringSlice())
+	assert.Contains(t, eventAttrs["exception.stacktrace"].AsString(), "\tunavailable: DB.Query: database is down\n")
+	assert.Equal(t, "handler: internal: could not load user\nunavailable: DB.Query: database is down",
+		eventAttrs["exception.message"].AsString())
+}
+
+func TestRecordError_Status(t *testing.T) {
+	testcases := []struct {
+		name   string
+		err    error
+		expect otelcodes.Code
+	}{
+		{"internal", errs.B().Code(errs.Internal).Err(), otelcodes.Error},
+		{"unknown", io.EOF, otelcodes.Error},
+		{"data loss", errs.B().Code(errs.DataLoss).Err(), otelcodes.Error},
+		{"unavailable", errs.B().Code(errs.Unavailable).Err(), otelcodes.Error},
+		{"not found", errs.B().Code(errs.NotFound).Op("Users.Get").Err(), otelcodes.Unset},
+		{"invalid argument", errs.B().Code(errs.InvalidArgument).Err(), otelcodes.Unset},
+		{"multi error", errs.MultiB().Add(errs.B().Code(errs.InvalidArgument).Err()).ErrOrNil(), otelcodes.Unset},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			span := record(t, tc.err)
+			assert.Equal(t, tc.expect, span.Status().Code)
+			assert.Len(t, span.Events(), 1)
+		})
+	}
+}
+
+func TestRecordError_Nothing(t *testing.T) {
+	span := record(t, nil)
+	assert.Empty(t, span.Events())
+	assert.Empty(t, span.Attributes())
+
+	assert.NotPanics(t, func() {
+		_, span := noop.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+		RecordError(span, io.EOF)
+	})
+}
@@ -0,0 +1,263 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+)
+
+// defaultRegistry is the Registry used by the methods of Code and the package level registration functions.
+var defaultRegistry = new(Registry)
+
+// Registry contains custom codes and overrides of existing codes.
+//
+// A Registry is safe for concurrent use. It is optimized for lookups: registrations copy the mappings and resolve
+// the specs of all registered codes, while lookups read the resolved specs without locking.
+//
+// The methods of Code and the functions RegisterCode, UnregisterCode, IsRegistered and ClearCodeRegister use
+// a default Registry. Separate registries can be created with NewRegistry, for example in parallel tests,
+// to use isolated mappings. The zero value is an empty Registry ready to use.
+//
+// Errors are rendered without a Registry, so the names, descriptions and public messages in their text and JSON
+// always come from the default Registry. Other registries apply where errors cross a transport: the adapters take
+// them as options, e.g. httperr.WithRegistry and status.WithRegistry, and map the codes of the errors they write
+// to HTTP statuses, gRPC codes and problem details with them.
+type Registry struct {
+	// mu serializes registrations
+	mu sync.Mutex
+
+	// entries contains the current specs, the map is never modified once stored
+	entries atomic.Pointer[map[Code]entry]
+}
+
+// entry is a registered code.
+type entry struct {
+	// spec is the registered spec, it is kept to resolve the spec again when its parents change
+	spec CodeSpec
+	// resolved is the spec with its unset fields resolved, see CodeSpec
+	resolved CodeSpec
+}
+
+// DefaultRegistry returns the Registry used by the methods of Code and the package level registration functions.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// NewRegistry returns an empty Registry. Only the default mappings of codes are available in it.
+func NewRegistry() *Registry {
+	return new(Registry)
+}
+
+// Register registers a new code OR overrides an existing one. It is a shorthand for RegisterSpec.
+func (r *Registry) Register(c Code, HTTP int, GRPC codes.Code, desc string, opts ...CodeOption) {
+	spec := CodeSpec{Description: desc, HTTP: HTTP, GRPC: GRPC}
+	for _, opt := range opts {
+		opt(&spec)
+	}
+	r.RegisterSpec(c, spec)
+}
+
+// Unregister unregisters the custom implementation or override of a code provided with Register.
+// When a code is not registered, Unregister is a no-op.
+func (r *Registry) Unregister(c Code) {
+	r.update(func(m map[Code]CodeSpec) {
+		delete(m, c)
+	})
+}
+
+// IsRegistered returns true if a custom implementation or override is being used for the code.
+func (r *Registry) IsRegistered(c Code) bool {
+	_, ok := r.lookup(c)
+	return ok
+}
+
+// Clear removes all registrations made with Register.
+func (r *Registry) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries.Store(nil)
+}
+
+// String returns the string representation of the code, which is its name, see CodeSpec.Name.
+func (r *Registry) String(c Code) string {
+	if s, ok := r.lookup(c); ok {
+		return s.Name
+	}
+	if !c.builtin() {
+		return valueName(c)
+	}
+	return codeNames[c]
+}
+
+// HTTP returns the HTTP code that is mapped to the code.
+func (r *Registry) HTTP(c Code) int {
+	if s, ok := r.lookup(c); ok {
+		return s.HTTP
+	}
+	return httpCodes[c.fallback()]
+}
+
+// GRPC returns the gPRC code that is mapped to the code.
+func (r *Registry) GRPC(c Code) codes.Code {
+	if s, ok := r.lookup(c); ok {
+		return s.GRPC
+	}
+	return grpcCodes[c.fallback()]
+}
+
+// Retryable reports whether errors with the code are retryable, see Code.Retryable.
+func (r *Registry) Retryable(c Code) bool {
+	if s, ok := r.lookup(c); ok {
+		return *s.Retryable
+	}
+	return retryableGRPC(grpcCodes[c.fallback()])
+}
+
+// Codes returns the built-in codes followed by the registered codes that are not built-in, in ascending order.
+func (r *Registry) Codes() []Code {
+	result := make([]Code, 0, CodeSize)
+	for c := Code(0); c < CodeSize; c++ {
+		result = append(result, c)
+	}
+	if m := r.entries.Load(); m != nil {
+		for _, c := range slices.Sorted(maps.Keys(*m)) {
+			if !c.builtin() {
+				result = append(result, c)
+			}
+		}
+	}
+	return result
+}
+
+// ParseCode returns the code whose name or description is s, see the ParseCode function.
+func (r *Registry) ParseCode(s string) (Code, error) {
+	if v, ok := strings.CutPrefix(s, "code("); ok {
+		if n, err := strconv.Atoi(strings.TrimSuffix(v, ")")); err == nil && strings.HasSuffix(v, ")") {
+			return Code(n), nil
+		}
+	}
+	all := r.Codes()
+	for _, c := range slices.Backward(all) {
+		if r.String(c) == s {
+			return c, nil
+		}
+	}
+	for _, c := range all {
+		if r.resolved(c).Description == s {
+			return c, nil
+		}
+	}
+	return Unknown, fmt.Errorf("errs: unknown code %q", s)
+}
+
+// Validate is a strict check of the registrations for the codes cs, which is meant to run once they are registered,
+// e.g. in tests or at the start of programs. Lookups of codes that are not registered do not fail but fall back to
+// the mappings of Unknown, so Validate reports them instead. The returned error joins an error for each of:
+//
+// - codes of cs that are neither built-in nor registered.
+//
+// - registered codes whose parent is neither built-in nor registered, or whose parents form a cycle.
+//
+// - codes that have the same name, which cannot be parsed back with ParseCode.
+//
+// - registered codes that are not built-in and have no name, whose name is derived from their description and so
+// changes with it, see WithName.
+func (r *Registry) Validate(cs ...Code) error {
+	var problems []error
+	for _, c := range cs {
+		if !c.builtin() && !r.IsRegistered(c) {
+			problems = append(problems, fmt.Errorf("errs: %s is not registered", valueName(c)))
+		}
+	}
+
+	names := make(map[string]Code)
+	for _, c := range r.Codes() {
+		name := r.String(c)
+		if other, ok := names[name]; ok {
+			problems = append(problems, fmt.Errorf("errs: %s and %s have the same name %q", valueName(other), valueName(c), name))
+		}
+		names[name] = c
+
+		if spec, ok := r.registered(c); ok && !c.builtin() && spec.Name == "" {
+			problems = append(problems, fmt.Errorf("errs: the name %q of %s is derived from its description", name, valueName(c)))
+		}
+
+		s, ok := r.lookup(c)
+		if !ok || s.Parent == Unknown {
+			continue
+		}
+		if err := r.validateParents(c, s.Parent); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// validateParents reports a parent of c that is neither built-in nor registered, or a cycle of parents.
+func (r *Registry) validateParents(c, parent Code) error {
+	for i := 0; i < maxParents; i++ {
+		if parent == c {
+			return fmt.Errorf("errs: the parents of %s form a cycle", r.String(c))
+		}
+		if parent == Unknown {
+			return nil
+		}
+		s, ok := r.lookup(parent)
+		if !ok {
+			if parent.builtin() {
+				return nil
+			}
+			return fmt.Errorf("errs: the parent %s of %s is not registered", valueName(parent), r.String(c))
+		}
+		parent = s.Parent
+	}
+	return fmt.Errorf("errs: %s has more than %d parents", r.String(c), maxParents)
+}
+
+// lookup returns the resolved spec of a registered code. Its Retryable flag is shared and must not be modified.
+func (r *Registry) lookup(c Code) (CodeSpec, bool) {
+	m := r.entries.Load()
+	if m == nil {
+		return CodeSpec{}, false
+	}
+	x, ok := (*m)[c]
+	return x.resolved, ok
+}
+
+// registered returns the spec a code was registered with, before its unset fields are resolved.
+func (r *Registry) registered(c Code) (CodeSpec, bool) {
+	m := r.entries.Load()
+	if m == nil {
+		return CodeSpec{}, false
+	}
+	x, ok := (*m)[c]
+	return x.spec, ok
+}
+
+// update applies fn to a copy of the registered specs, resolves them and stores them.
+// All specs are resolved again as fn may change the parents of any code.
+func (r *Registry) update(fn func(specs map[Code]CodeSpec)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	specs := make(map[Code]CodeSpec)
+	if old := r.entries.Load(); old != nil {
+		for c, x := range *old {
+			specs[c] = x.spec
+		}
+	}
+	fn(specs)
+
+	m := make(map[Code]entry, len(specs))
+	for c, spec := range specs {
+		m[c] = entry{spec: spec, resolved: resolve(specs, c, 0)}
+	}
+	r.entries.Store(&m)
+}
@@ -0,0 +1,141 @@
+package errs
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("zero value", func(t *testing.T) {
+		var r Registry
+		assert.False(t, r.IsRegistered(NotFound))
+		assert.Equal(t, "not_found", r.String(NotFound))
+		assert.Equal(t, 404, r.HTTP(NotFound))
+		assert.Equal(t, codes.NotFound, r.GRPC(NotFound))
+	})
+	t.Run("isolated from the default registry", func(t *testing.T) {
+		t.Parallel()
+		r := NewRegistry()
+		r.Register(NotFound, 410, codes.Unavailable, "gone")
+
+		assert.True(t, r.IsRegistered(NotFound))
+		assert.Equal(t, "not_found", r.String(NotFound), "overrides keep the default name")
+		assert.Equal(t, "gone", r.Spec(NotFound).Description)
+		assert.Equal(t, 410, r.HTTP(NotFound))
+		assert.Equal(t, codes.Unavailable, r.GRPC(NotFound))
+		c, err := r.ParseCode("gone")
+		require.NoError(t, err)
+		assert.Equal(t, NotFound, c)
+
+		assert.False(t, IsRegistered(NotFound))
+		assert.Equal(t, "not_found", NotFound.String())
+	})
+	t.Run("unregister and clear", func(t *testing.T) {
+		t.Parallel()
+		r := NewRegistry()
+		r.Register(Code(100), 100, codes.Code(100), "first")
+		r.Register(Code(101), 101, codes.Code(101), "second")
+
+		r.Unregister(Code(100))
+		assert.False(t, r.IsRegistered(Code(100)))
+		assert.True(t, r.IsRegistered(Code(101)))
+
+		r.Clear()
+		assert.False(t, r.IsRegistered(Code(101)))
+	})
+}
+
+// TestRegistry_concurrent is meant to be run with the race detector.
+func TestRegistry_concurrent(t *testing.T) {
+	r := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		c := Code(CodeSize + i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Register(c, 400+i, codes.Code(i), "code"+strconv.Itoa(i))
+				r.Unregister(c)
+			}
+			r.Register(c, 400+i, codes.Code(i), "code"+strconv.Itoa(i))
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = r.HTTP(NotFound)
+				_ = r.GRPC(NotFound)
+				_ = r.String(NotFound)
+				_ = r.IsRegistered(c)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, 400+i, r.HTTP(Code(CodeSize+i)))
+	}
+}
+
+func TestRegistry_unregisteredCodes(t *testing.T) {
+	r := NewRegistry()
+	for _, c := range []Code{CodeSize + 3, -1} {
+		assert.Equal(t, "code("+strconv.Itoa(int(c))+")", r.String(c))
+		assert.Equal(t, 500, r.HTTP(c))
+		assert.Equal(t, codes.Unknown, r.GRPC(c))
+		assert.False(t, r.Retryable(c))
+		assert.Equal(t, SeverityError, r.Spec(c).Severity)
+
+		parsed, err := r.ParseCode(r.String(c))
+		require.NoError(t, err)
+		assert.Equal(t, c, parsed)
+	}
+
+	c := Code(CodeSize + 4)
+	r.RegisterSpec(c, CodeSpec{})
+	assert.Equal(t, "code(19)", r.String(c), "registered codes without name or description")
+	assert.Equal(t, 500, r.HTTP(c))
+	assert.Equal(t, codes.Unknown, r.GRPC(c))
+
+	t.Run("errors", func(t *testing.T) {
+		c := Code(CodeSize + 3)
+		e := B().Code(c).Op("Pay").Msg("declined").Err().(*Error)
+		assert.NotPanics(t, func() {
+			assert.Equal(t, "code(18): Pay: declined", e.Error())
+			assert.Equal(t, codes.Unknown, e.GRPCStatus().Code())
+			assert.Equal(t, "errs.Code(18)", c.GoString())
+		})
+	})
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	r := NewRegistry()
+	assert.NoError(t, r.Validate(NotFound, Internal))
+
+	declined, expired, orphan := Code(CodeSize+5), Code(CodeSize+6), Code(CodeSize+7)
+	r.RegisterSpec(declined, CodeSpec{Name: "payment_declined", Parent: FailedPrecondition})
+	r.RegisterSpec(expired, CodeSpec{Name: "card_expired", Parent: declined})
+	assert.NoError(t, r.Validate(declined, expired))
+
+	err := r.Validate(declined, orphan)
+	assert.EqualError(t, err, "errs: code(22) is not registered")
+
+	r.RegisterSpec(orphan, CodeSpec{Name: "orphan", Parent: CodeSize + 8})
+	r.RegisterSpec(CodeSize+9, CodeSpec{Name: "card_expired", Parent: CodeSize + 10})
+	r.RegisterSpec(CodeSize+10, CodeSpec{Name: "loop", Parent: CodeSize + 9})
+	err = r.Validate(declined, orphan)
+	require.Error(t, err)
+	for _, msg := range []string{
+		"errs: the parent code(23) of orphan is not registered",
+		`errs: code(21) and code(24) have the same name "card_expired"`,
+		"errs: the parents of card_expired form a cycle",
+		"errs: the parents of loop form a cycle",
+	} {
+		assert.Contains(t, err.Error(), msg)
+	}
+}
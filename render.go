@@ -0,0 +1,113 @@
+package errs
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+)
+
+// RenderMode defines what information of an error is sent to clients by SafeError and the encoders
+// built on it: MarshalJSON, GRPCStatus and the httperr package.
+type RenderMode int32
+
+const (
+	// RenderSafe never renders internal messages, and omits the operation of errors with redacted codes,
+	// see Code.Redacted. It is the default mode.
+	RenderSafe RenderMode = iota
+
+	// RenderFull renders everything Error does, including internal messages.
+	RenderFull
+)
+
+// renderMode is the mode used by SafeError, see SetRenderMode.
+var renderMode atomic.Int32
+
+// SetRenderMode sets the RenderMode used to send errors to clients. RenderFull should only be used in development.
+func SetRenderMode(m RenderMode) {
+	renderMode.Store(int32(m))
+}
+
+// Redacted reports whether only the messages of errors with the code are sent to clients in RenderSafe mode.
+// Codes mapped to the Internal, Unknown or DataLoss gRPC codes are redacted.
+func (c Code) Redacted() bool {
+	switch c.GRPC() {
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// full reports whether errors are rendered in RenderFull mode.
+func full() bool {
+	return RenderMode(renderMode.Load()) == RenderFull
+}
+
+// SafeError returns the error like Error, without the information that must not be sent to clients:
+// internal messages are left out, and so are the operations of errors with redacted codes.
+// Errors without messages are rendered with the public message of their code, see CodeSpec.
+//
+// In RenderFull mode, SafeError returns the same as Error.
+func (e *Error) SafeError() string {
+	if e == nil {
+		return ""
+	}
+	if full() {
+		return e.Error()
+	}
+
+	var buf strings.Builder
+	e.writeSafeTo(&buf)
+	for inner := range shown(e.cause) {
+		buf.WriteString("\n")
+		inner.writeSafeTo(&buf)
+	}
+	return buf.String()
+}
+
+// SafeOp returns the operation of the error if it can be sent to clients, see SafeError.
+func (e *Error) SafeOp() string {
+	if !full() && e.Code.Redacted() {
+		return ""
+	}
+	return e.Op
+}
+
+// publicMsg returns the messages of the error, or the public message of its code if it has none.
+func (e *Error) publicMsg() []string {
+	if len(e.Msg) == 0 {
+		if msg := e.Code.PublicMessage(); msg != "" {
+			return []string{msg}
+		}
+	}
+	return e.Msg
+}
+
+// safeMsg returns the messages of the error that can be sent to clients, see SafeError.
+func (e *Error) safeMsg() []string {
+	if full() {
+		return append(cleanStrings(e.publicMsg()), cleanStrings(e.InternalMsg)...)
+	}
+	return e.publicMsg()
+}
+
+// safeString returns the error like String, with the information returned by SafeError.
+func (e *Error) safeString() string {
+	if full() {
+		return e.String()
+	}
+	var buf strings.Builder
+	e.writeSafeTo(&buf)
+	return buf.String()
+}
+
+func (e *Error) writeSafeTo(buf *strings.Builder) {
+	buf.WriteString(e.Code.String())
+	if op := e.SafeOp(); op != "" {
+		buf.WriteString(Separator + op)
+	}
+	if msgs := strings.Join(cleanStrings(e.publicMsg()), Separator); msgs != "" {
+		buf.WriteString(Separator + msgs)
+	}
+}
@@ -0,0 +1,112 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode_Redacted(t *testing.T) {
+	for _, c := range []Code{Internal, Unknown, DataLoss} {
+		assert.True(t, c.Redacted(), c.String())
+	}
+	for _, c := range []Code{NotFound, InvalidArgument, Unavailable} {
+		assert.False(t, c.Redacted(), c.String())
+	}
+}
+
+func TestError_SafeError(t *testing.T) {
+	query := errors.New("pq: relation \"users\" does not exist")
+	testcases := []struct {
+		name   string
+		err    error
+		full   string
+		expect string
+	}{
+		{
+			name:   "internal messages are left out",
+			err:    B().Code(NotFound).Op("Users.Get").Msg("user not found").InternalMsg("id=42").Err(),
+			full:   "not_found: Users.Get: user not found: id=42",
+			expect: "not_found: Users.Get: user not found",
+		},
+		{
+			name:   "operation of redacted code is left out",
+			err:    B().Code(Internal).Op("Users.Get").Msg("something went wrong").Err(),
+			full:   "internal: Users.Get: something went wrong",
+			expect: "internal: something went wrong",
+		},
+		{
+			name:   "foreign error text is internal",
+			err:    WrapMsg(query, "could not fetch user"),
+			full:   "unknown: could not fetch user",
+			expect: "unknown: could not fetch user",
+		},
+		{
+			name: "shown causes",
+			err: WrapCode(
+				B().Code(InvalidArgument).Op("Users.Validate").Msg("name is required").InternalMsg("name=\"\"").Show().Err(),
+				Internal,
+			),
+			full:   "invalid_argument: Users.Validate: name is required: name=\"\"",
+			expect: "internal\ninvalid_argument: Users.Validate: name is required",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := tc.err.(*Error)
+			assert.Equal(t, tc.expect, e.SafeError())
+			assert.Contains(t, e.Error(), tc.full)
+		})
+	}
+}
+
+func TestSetRenderMode(t *testing.T) {
+	err := B().Code(Internal).Op("Users.Get").Msg("something went wrong").InternalMsg("connection refused").Err().(*Error)
+
+	SetRenderMode(RenderFull)
+	defer SetRenderMode(RenderSafe)
+	assert.Equal(t, err.Error(), err.SafeError())
+	assert.Equal(t, "Users.Get", err.SafeOp())
+
+	b, jsonErr := json.Marshal(err)
+	require.NoError(t, jsonErr)
+	assert.JSONEq(t, `{"op":"Users.Get","message":["something went wrong"],"internal":["connection refused"],"code":"internal"}`, string(b))
+}
+
+func TestError_MarshalJSON_Safe(t *testing.T) {
+	err := WrapCode(
+		B().Code(DataLoss).Op("Disk.Read").Msg("file is corrupted").InternalMsg("/var/data/1.db").Show().Err(),
+		Internal, "could not read file",
+	)
+
+	b, jsonErr := json.Marshal(err)
+	require.NoError(t, jsonErr)
+	assert.JSONEq(t, `{
+		"op":"","message":["could not read file"],"code":"internal",
+		"causes":[{"op":"","message":["file is corrupted"],"code":"data_loss"}]
+	}`, string(b))
+
+	b, jsonErr = json.Marshal(Debug(err))
+	require.NoError(t, jsonErr)
+	assert.Contains(t, string(b), `"op":"Disk.Read"`)
+	assert.Contains(t, string(b), `"internal":["/var/data/1.db"]`)
+}
+
+func TestError_GRPCStatus_Safe(t *testing.T) {
+	err := B().Code(Unknown).Op("Users.Get").Msg("could not fetch user").InternalMsg("dial tcp 10.0.0.1:5432").Err().(*Error)
+
+	st := err.GRPCStatus()
+	assert.Equal(t, "unknown: could not fetch user", st.Message())
+	for _, d := range st.Details() {
+		assert.NotContains(t, d.(interface{ String() string }).String(), "10.0.0.1")
+		assert.NotContains(t, d.(interface{ String() string }).String(), "Users.Get")
+	}
+}
+
+func TestError_Is_InternalMsg(t *testing.T) {
+	assert.NotErrorIs(t, Convert(errors.New("a")), Convert(errors.New("b")))
+	assert.ErrorIs(t, B().InternalMsg("a").Err(), B().InternalMsg("a").Err())
+}
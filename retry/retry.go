@@ -0,0 +1,107 @@
+// Package retry retries operations that fail with retryable errors, see errs.Retryable.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+// Backoff returns the delay to wait after the attempt failed, attempts start at 1.
+type Backoff func(attempt int) time.Duration
+
+// Exponential returns a Backoff that doubles the delay after each attempt, starting at initial and capped at limit.
+// Delays are randomized between half and all of their value, to spread the retries of concurrent callers.
+func Exponential(initial, limit time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		d := limit
+		// comparing with limit shifted right keeps initial shifted left from overflowing
+		if shift := max(attempt-1, 0); shift < 63 && initial <= limit>>shift {
+			d = initial << shift
+		}
+		if d <= 1 {
+			return d
+		}
+		return d/2 + rand.N(d/2)
+	}
+}
+
+// Option configures Do.
+type Option func(*options)
+
+type options struct {
+	attempts int
+	backoff  Backoff
+}
+
+// Attempts sets the maximum number of attempts, including the first one. It is 3 by default.
+func Attempts(n int) Option {
+	return func(o *options) {
+		o.attempts = n
+	}
+}
+
+// WithBackoff sets the Backoff between attempts. It is Exponential(100*time.Millisecond, 10*time.Second) by default.
+func WithBackoff(b Backoff) Option {
+	return func(o *options) {
+		o.backoff = b
+	}
+}
+
+// Do calls fn until it succeeds, fails with an error that is not retryable, runs out of attempts or ctx is done.
+// Between attempts, it waits for the delay of the Backoff, or for the retry hint of the error when it is longer,
+// see errs.RetryAfter.
+//
+// When fn does not succeed, Do returns a copy of the last error made with errs.B, so it has the same code, matches
+// the last error with errors.Is and is sent to clients the same way. The number of attempts and the errors of all
+// attempts are added to its internal messages, and the error of ctx is added to its details if ctx is done.
+func Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	o := &options{attempts: 3, backoff: Exponential(100*time.Millisecond, 10*time.Second)}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var attempts []string
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		attempts = append(attempts, fmt.Sprintf("attempt %d: %v", attempt, err))
+		if !errs.Retryable(err) || attempt >= o.attempts {
+			return failed(err, attempts, nil)
+		}
+
+		delay := o.backoff(attempt)
+		if hint, ok := errs.RetryAfter(err); ok && hint > delay {
+			delay = hint
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return failed(err, attempts, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// failed returns the error returned by Do when the last attempt failed with err.
+func failed(err error, attempts []string, ctxErr error) error {
+	msg := "failed after 1 attempt"
+	if len(attempts) > 1 {
+		msg = fmt.Sprintf("failed after %d attempts", len(attempts))
+	}
+	// internal messages are never sent to clients, which see the last error unchanged
+	b := errs.B(err).
+		InternalMsg(msg).
+		InternalMsg(attempts...).
+		With("attempts", len(attempts))
+	if ctxErr != nil {
+		b.Details(ctxErr)
+	}
+	return b.Err()
+}
@@ -0,0 +1,117 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+// noBackoff makes tests retry immediately.
+var noBackoff = WithBackoff(func(int) time.Duration { return 0 })
+
+// failing returns a function that fails with results in order, then succeeds. calls counts the calls.
+func failing(calls *int, results ...error) func(context.Context) error {
+	return func(context.Context) error {
+		*calls++
+		if *calls <= len(results) {
+			return results[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestDo(t *testing.T) {
+	unavailable := errs.B().Code(errs.Unavailable).Msg("database is down").Err()
+	notFound := errs.B().Code(errs.NotFound).Msg("user not found").Err()
+
+	t.Run("success after retries", func(t *testing.T) {
+		var calls int
+		err := Do(context.Background(), failing(&calls, unavailable, unavailable), noBackoff)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+	t.Run("attempts exhausted", func(t *testing.T) {
+		var calls int
+		err := Do(context.Background(), failing(&calls, unavailable, unavailable, unavailable), noBackoff)
+		require.Error(t, err)
+		assert.Equal(t, 3, calls)
+		assert.ErrorIs(t, err, unavailable)
+		assert.Equal(t, errs.Unavailable, errs.CodeOf(err))
+
+		var e *errs.Error
+		require.True(t, errors.As(err, &e))
+		assert.Equal(t, []string{"database is down"}, e.Msg)
+		assert.Equal(t, []string{
+			"failed after 3 attempts",
+			"attempt 1: unavailable: database is down",
+			"attempt 2: unavailable: database is down",
+			"attempt 3: unavailable: database is down",
+		}, e.InternalMsg)
+		n, _ := errs.Attr[int](err, "attempts")
+		assert.Equal(t, 3, n)
+	})
+	t.Run("not retryable", func(t *testing.T) {
+		var calls int
+		err := Do(context.Background(), failing(&calls, notFound), noBackoff, Attempts(5))
+		assert.Equal(t, 1, calls)
+		assert.ErrorIs(t, err, notFound)
+		assert.Equal(t, errs.NotFound, errs.CodeOf(err))
+		assert.Equal(t, []string{"failed after 1 attempt", "attempt 1: not_found: user not found"}, err.(*errs.Error).InternalMsg)
+		assert.Equal(t, notFound.(*errs.Error).SafeError(), err.(*errs.Error).SafeError(), "clients see the last error")
+		assert.Equal(t, "not_found: user not found", notFound.Error(), "the last error is not modified")
+	})
+	t.Run("foreign errors are classified", func(t *testing.T) {
+		var calls int
+		err := Do(context.Background(), failing(&calls, io.EOF), noBackoff)
+		assert.Equal(t, 1, calls)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls int
+		fn := func(context.Context) error {
+			calls++
+			cancel()
+			return unavailable
+		}
+		err := Do(ctx, fn, WithBackoff(func(int) time.Duration { return time.Hour }))
+		assert.Equal(t, 1, calls)
+		assert.ErrorIs(t, err, unavailable)
+		assert.Equal(t, []any{context.Canceled}, err.(*errs.Error).Details)
+	})
+	t.Run("retry hint is respected", func(t *testing.T) {
+		var calls int
+		hinted := errs.B().Code(errs.ResourceExhausted).RetryAfter(20 * time.Millisecond).Err()
+		start := time.Now()
+		err := Do(context.Background(), failing(&calls, hinted), noBackoff)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+}
+
+func TestExponential(t *testing.T) {
+	b := Exponential(100*time.Millisecond, time.Second)
+	for attempt, upper := range map[int]time.Duration{
+		1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second, 100: time.Second,
+	} {
+		d := b(attempt)
+		assert.LessOrEqual(t, d, upper, "attempt %d", attempt)
+		assert.GreaterOrEqual(t, d, upper/2, "attempt %d", attempt)
+	}
+}
+
+func TestExponential_largeAttempts(t *testing.T) {
+	b := Exponential(5*time.Second, time.Hour)
+	for _, attempt := range []int{12, 32, 33, 63, 64, 1000} {
+		d := b(attempt)
+		assert.LessOrEqual(t, d, time.Hour, "attempt %d", attempt)
+		assert.GreaterOrEqual(t, d, time.Hour/2, "attempt %d", attempt)
+	}
+}
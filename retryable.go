@@ -0,0 +1,59 @@
+package errs
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// CodeOption configures the CodeSpec of a code registered with RegisterCode.
+type CodeOption func(*CodeSpec)
+
+// WithRetryable sets whether errors with the code are retryable, see Retryable.
+// By default, registered codes are retryable if the gRPC code they are mapped to is.
+func WithRetryable(retryable bool) CodeOption {
+	return func(s *CodeSpec) {
+		s.Retryable = &retryable
+	}
+}
+
+// Retryable reports whether the operation that failed with an error with the code can be retried.
+// The Unavailable, ResourceExhausted, Aborted and DeadlineExceeded codes are retryable by default, and so are
+// the codes mapped to their gRPC codes. The defaults can be overridden with WithRetryable.
+func (c Code) Retryable() bool {
+	return defaultRegistry.Retryable(c)
+}
+
+// Retryable reports whether the operation that failed with err can be retried,
+// from the code returned by CodeOf for err, see Code.Retryable. It returns false if err is nil.
+func Retryable(err error) bool {
+	return err != nil && CodeOf(err).Retryable()
+}
+
+// retryableGRPC reports whether the gRPC code is retryable by default.
+func retryableGRPC(c codes.Code) bool {
+	switch c {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryAfter sets the duration clients should wait before retrying. It is sent as the Retry-After header by
+// the httperr package and as errdetails.RetryInfo by GRPCStatus.
+func (b *Builder) RetryAfter(d time.Duration) *Builder {
+	b.err.retryAfter = d
+	return b
+}
+
+// RetryAfter returns the first duration set with Builder.RetryAfter in the tree of err, walking it like CodeOf.
+func RetryAfter(err error) (d time.Duration, ok bool) {
+	walk(err, func(err error) bool {
+		if x, isErr := err.(*Error); isErr && x.retryAfter > 0 {
+			d, ok = x.retryAfter, true
+		}
+		return !ok
+	})
+	return d, ok
+}
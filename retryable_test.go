@@ -0,0 +1,79 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRetryable(t *testing.T) {
+	testcases := []struct {
+		name   string
+		err    error
+		expect bool
+	}{
+		{"nil", nil, false},
+		{"unavailable", B().Code(Unavailable).Err(), true},
+		{"resource exhausted", B().Code(ResourceExhausted).Err(), true},
+		{"aborted", B().Code(Aborted).Err(), true},
+		{"deadline exceeded", B().Code(DeadlineExceeded).Err(), true},
+		{"invalid argument", B().Code(InvalidArgument).Err(), false},
+		{"not found", B().Code(NotFound).Err(), false},
+		{"internal", B().Code(Internal).Err(), false},
+		{"foreign error", io.EOF, false},
+		{"classified foreign error", fmt.Errorf("query: %w", context.DeadlineExceeded), true},
+		{"outer code wins", WrapCode(B().Code(Unavailable).Err(), InvalidArgument), false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, Retryable(tc.err))
+		})
+	}
+}
+
+func TestRegistry_Retryable(t *testing.T) {
+	r := NewRegistry()
+	custom := Code(CodeSize + 20)
+
+	r.Register(custom, 503, codes.Unavailable, "maintenance")
+	assert.True(t, r.Retryable(custom), "retryable from the gRPC code by default")
+
+	r.Register(custom, 503, codes.Unavailable, "maintenance", WithRetryable(false))
+	assert.False(t, r.Retryable(custom))
+
+	r.Register(NotFound, 404, codes.NotFound, "not_found", WithRetryable(true))
+	assert.True(t, r.Retryable(NotFound), "built-in codes can be overridden")
+	assert.False(t, NotFound.Retryable(), "the default registry is not changed")
+}
+
+func TestRetryAfter(t *testing.T) {
+	hinted := B().Code(Unavailable).RetryAfter(5 * time.Second).Err()
+
+	d, ok := RetryAfter(fmt.Errorf("x: %w", WrapCode(hinted, Internal)))
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	d, ok = RetryAfter(errors.Join(io.EOF, MultiB().Add(hinted).ErrOrNil()))
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	_, ok = RetryAfter(B().Code(Unavailable).Err())
+	assert.False(t, ok)
+
+	var info *errdetails.RetryInfo
+	for _, detail := range hinted.(*Error).GRPCStatus().Details() {
+		if ri, ok := detail.(*errdetails.RetryInfo); ok {
+			info = ri
+		}
+	}
+	if assert.NotNil(t, info) {
+		assert.Equal(t, 5*time.Second, info.GetRetryDelay().AsDuration())
+	}
+}
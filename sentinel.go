@@ -0,0 +1,66 @@
+package errs
+
+// MatchMode defines how errors are matched against a sentinel error with errors.Is.
+type MatchMode int
+
+const (
+	// MatchIdentity matches errors derived from the sentinel with B, Wrap, WrapB, WrapCode or WrapMsg,
+	// whatever messages, operations or details were added to them.
+	// Other errors never match, even when their code and messages are equal to the sentinel's.
+	MatchIdentity MatchMode = iota
+
+	// MatchCode matches errors that have the same code as the sentinel.
+	MatchCode
+
+	// MatchStructural matches errors with the same code, operation and messages as the sentinel.
+	// This is how errors that are not sentinels are matched.
+	MatchStructural
+)
+
+// sentinel contains the matching options of a sentinel error.
+type sentinel struct {
+	mode MatchMode
+}
+
+// Sentinel returns a new sentinel error with the code and the name as message, to be declared as a package variable:
+//
+//	var ErrUserNotFound = errs.Sentinel(errs.NotFound, "user not found")
+//
+//	err := errs.B(ErrUserNotFound).Msg("id 42").Err() // errors.Is(err, ErrUserNotFound) == true
+//
+// Errors are matched with the sentinel by identity, an optional MatchMode can be passed to choose another matching.
+// Sentinels are never modified by the functions of this package, errors derived from them are copies.
+func Sentinel(code Code, name string, mode ...MatchMode) error {
+	s := &sentinel{mode: MatchIdentity}
+	if len(mode) > 0 {
+		s.mode = mode[0]
+	}
+	return &Error{
+		Code:     code,
+		Msg:      cleanStrings([]string{name}),
+		sentinel: s,
+	}
+}
+
+// root returns the first error e was derived from with B, or e itself.
+func (e *Error) root() *Error {
+	for e.origin != nil {
+		e = e.origin
+	}
+	return e
+}
+
+// matchSentinel reports whether e matches the sentinel s and whether s is a sentinel at all.
+func (e *Error) matchSentinel(s *Error) (match, ok bool) {
+	if s.sentinel == nil {
+		return false, false
+	}
+	switch s.sentinel.mode {
+	case MatchIdentity:
+		return e.root() == s, true
+	case MatchCode:
+		return e.Code == s.Code, true
+	default:
+		return equalNodes(e, s) || equalNodes(e.root(), s), true
+	}
+}
@@ -0,0 +1,69 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentinel(t *testing.T) {
+	errUserNotFound := Sentinel(NotFound, "user not found")
+	errItemNotFound := Sentinel(NotFound, "item not found")
+
+	assert.Equal(t, "not_found: user not found", errUserNotFound.Error())
+
+	testcases := []struct {
+		name   string
+		err    error
+		expect bool
+	}{
+		{"sentinel itself", errUserNotFound, true},
+		{"builder", B(errUserNotFound).Msg("id 42").Op("Users.Get").Details(42).Err(), true},
+		{"builder of derived error", B(B(errUserNotFound).Msg("id 42").Err()).Code(Internal).Err(), true},
+		{"wrapped with WrapMsg", WrapMsg(errUserNotFound, "fetching user"), true},
+		{"wrapped with WrapCode", WrapCode(errUserNotFound, Internal, "fetching user"), true},
+		{"wrapped with WrapB", WrapB(errUserNotFound).Msg("fetching user").Err(), true},
+		{"wrapped by foreign error", fmt.Errorf("fetching user: %w", errUserNotFound), true},
+		{"parent in Wrap", Wrap(B().Msg("db error").Err(), errUserNotFound), true},
+		{"structurally equal error", B().Code(NotFound).Msg("user not found").Err(), false},
+		{"other sentinel", errItemNotFound, false},
+		{"derived from other sentinel", B(errItemNotFound).Msg("user not found").Err(), false},
+		{"nil", nil, false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, errors.Is(tc.err, errUserNotFound))
+		})
+	}
+
+	t.Run("derived target", func(t *testing.T) {
+		derived := B(errUserNotFound).Msg("id 42").Err()
+		assert.ErrorIs(t, B(derived).Op("Users.Get").Err(), derived)
+		assert.ErrorIs(t, B(errUserNotFound).Msg("id 42").Err(), derived, "equal errors match")
+		assert.False(t, errors.Is(B(errUserNotFound).Msg("id 43").Err(), derived), "errors derived from the same sentinel")
+		assert.False(t, errors.Is(errUserNotFound, derived), "the sentinel does not match the more specific error")
+	})
+	t.Run("sentinel is never modified", func(t *testing.T) {
+		_ = B(errUserNotFound).Code(Internal).Msg("id 42").Err()
+		_ = Wrap(B().Msg("db error").Err(), errUserNotFound)
+		assert.Equal(t, "not_found: user not found", errUserNotFound.Error())
+		assert.Nil(t, errors.Unwrap(errUserNotFound))
+	})
+}
+
+func TestSentinel_MatchMode(t *testing.T) {
+	t.Run("code", func(t *testing.T) {
+		errNotFound := Sentinel(NotFound, "not found", MatchCode)
+		assert.ErrorIs(t, B().Code(NotFound).Msg("user not found").Err(), errNotFound)
+		assert.ErrorIs(t, WrapMsg(B().Code(NotFound).Err(), "fetching user"), errNotFound)
+		assert.False(t, errors.Is(B().Code(Internal).Err(), errNotFound))
+	})
+	t.Run("structural", func(t *testing.T) {
+		errNotFound := Sentinel(NotFound, "user not found", MatchStructural)
+		assert.ErrorIs(t, B().Code(NotFound).Msg("user not found").Err(), errNotFound)
+		assert.ErrorIs(t, B(errNotFound).Msg("id 42").Err(), errNotFound)
+		assert.False(t, errors.Is(B().Code(NotFound).Msg("item not found").Err(), errNotFound))
+	})
+}
@@ -0,0 +1,140 @@
+// Package slogerr integrates errs with log/slog.
+//
+// *errs.Error and *errs.MultiError implement slog.LogValuer, so they are logged as groups when they are logged
+// directly. The Handler of this package also expands them when they are wrapped by other errors, and can raise
+// the level of records from the codes of the errors they contain.
+package slogerr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// RaiseLevel makes the Handler raise the level of records to the level returned by fn for the codes of the errors
+// they contain. The level of a record is never lowered. Use Level for the levels of the codes.
+//
+// Since the level of a record is only known once its attributes are, records below the level enabled by the
+// wrapped handler are not dropped by Enabled anymore, but by Handle.
+func RaiseLevel(fn func(errs.Code) slog.Level) Option {
+	return func(h *Handler) {
+		h.level = fn
+	}
+}
+
+// Level returns the log level of the code, see errs.Code.LogLevel. By default, it is slog.LevelError for codes
+// mapped to 5xx HTTP codes and slog.LevelInfo for the others.
+func Level(c errs.Code) slog.Level {
+	return c.LogLevel()
+}
+
+// Handler is a slog.Handler that expands errors wrapping an *errs.Error or an *errs.MultiError in the attributes
+// of records, including the attributes in groups, before passing them to the wrapped handler.
+//
+// Expanded errors are logged as a group with their message in the "error" attribute, followed by the attributes
+// of the *errs.Error or *errs.MultiError they wrap, see (*errs.Error).LogValue.
+type Handler struct {
+	next  slog.Handler
+	level func(errs.Code) slog.Level
+}
+
+// NewHandler returns a Handler that passes records to next.
+func NewHandler(next slog.Handler, opts ...Option) *Handler {
+	h := &Handler{next: next}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Enabled implements the slog.Handler interface.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.level != nil || h.next.Enabled(ctx, level)
+}
+
+// Handle implements the slog.Handler interface.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	level := r.Level
+	expanded := slog.NewRecord(r.Time, level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		expanded.AddAttrs(h.expand(a, &level))
+		return true
+	})
+	expanded.Level = level
+
+	if h.level != nil && !h.next.Enabled(ctx, level) {
+		return nil
+	}
+	return h.next.Handle(ctx, expanded)
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		// the level of records is only raised by their own attributes
+		expanded = append(expanded, h.expand(a, nil))
+	}
+	return &Handler{next: h.next.WithAttrs(expanded), level: h.level}
+}
+
+// WithGroup implements the slog.Handler interface.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), level: h.level}
+}
+
+// expand returns the attribute with the errors it contains expanded, and raises level to the levels of their codes.
+func (h *Handler) expand(a slog.Attr, level *slog.Level) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, 0, len(group))
+		for _, inner := range group {
+			attrs = append(attrs, h.expand(inner, level))
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	}
+
+	err, ok := a.Value.Any().(error)
+	if !ok {
+		return a
+	}
+	var (
+		e     *errs.Error
+		value slog.Value
+	)
+	m, ok := asMulti(err)
+	switch {
+	case ok:
+		value = m.LogValue()
+	case errors.As(err, &e):
+		value = e.LogValue()
+	default:
+		return a
+	}
+	if h.level != nil && level != nil {
+		*level = max(*level, h.level(errs.CodeOf(err)))
+	}
+	if err == error(e) || err == error(m) {
+		return slog.Attr{Key: a.Key, Value: value}
+	}
+	attrs := append([]slog.Attr{slog.String("error", err.Error())}, value.Group()...)
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+}
+
+// asMulti returns the *errs.MultiError in the tree of err when no *errs.Error wraps it.
+// An *errs.Error wrapping a MultiError is logged with its own attributes.
+func asMulti(err error) (*errs.MultiError, bool) {
+	var m *errs.MultiError
+	if !errors.As(err, &m) {
+		return nil, false
+	}
+	// the first *errs.Error is one of the errors of m when no *errs.Error wraps it
+	var e *errs.Error
+	return m, !errors.As(err, &e) || slices.Contains(m.Errors(), e)
+}
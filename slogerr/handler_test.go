@@ -0,0 +1,100 @@
+package slogerr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+func newLogger(buf *bytes.Buffer, level slog.Level, opts ...Option) *slog.Logger {
+	return slog.New(NewHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: level}), opts...))
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return record
+}
+
+func TestHandler_Expand(t *testing.T) {
+	notFound := errs.B().Code(errs.NotFound).Op("Users.Get").Msg("user not found").Err()
+
+	t.Run("wrapped error", func(t *testing.T) {
+		var buf bytes.Buffer
+		newLogger(&buf, slog.LevelInfo).Info("request failed", "err", fmt.Errorf("handler: %w", notFound))
+
+		assert.Equal(t, map[string]any{
+			"error":   "handler: not_found: Users.Get: user not found",
+			"code":    "not_found",
+			"op":      "Users.Get",
+			"message": []any{"user not found"},
+			"http":    float64(404),
+			"grpc":    "NotFound",
+		}, decode(t, &buf)["err"])
+	})
+	t.Run("errors in groups and handler attributes", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newLogger(&buf, slog.LevelInfo).With("first", fmt.Errorf("x: %w", notFound))
+		logger.Info("request failed", slog.Group("req", "err", fmt.Errorf("y: %w", notFound)))
+
+		record := decode(t, &buf)
+		assert.Equal(t, "not_found", record["first"].(map[string]any)["code"])
+		assert.Equal(t, "not_found", record["req"].(map[string]any)["err"].(map[string]any)["code"])
+	})
+	t.Run("error wrapping a multi error", func(t *testing.T) {
+		var buf bytes.Buffer
+		m := errs.MultiB().Add(errs.B().Code(errs.InvalidArgument).Msg("name is required").Err()).ErrOrNil()
+		newLogger(&buf, slog.LevelInfo).Info("request failed", "err", errs.WrapCode(m, errs.Unavailable, "validation service down"))
+
+		logged := decode(t, &buf)["err"].(map[string]any)
+		assert.Equal(t, "unavailable", logged["code"], "the code of the wrapping error is kept")
+		assert.Equal(t, []any{"validation service down"}, logged["message"])
+	})
+	t.Run("foreign errors are kept", func(t *testing.T) {
+		var buf bytes.Buffer
+		newLogger(&buf, slog.LevelInfo).Info("request failed", "err", io.EOF)
+		assert.Equal(t, "EOF", decode(t, &buf)["err"])
+	})
+}
+
+func TestHandler_RaiseLevel(t *testing.T) {
+	testcases := []struct {
+		name   string
+		err    error
+		level  slog.Level
+		expect string
+	}{
+		{"internal is raised to error", errs.B().Code(errs.Internal).Err(), slog.LevelInfo, "ERROR"},
+		{"not found stays info", errs.B().Code(errs.NotFound).Err(), slog.LevelInfo, "INFO"},
+		{"level is never lowered", errs.B().Code(errs.NotFound).Err(), slog.LevelWarn, "WARN"},
+		{"wrapped multi error", fmt.Errorf("x: %w", errs.MultiB().Add(errs.B().Code(errs.Unavailable).Err()).ErrOrNil()), slog.LevelInfo, "ERROR"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			newLogger(&buf, slog.LevelInfo, RaiseLevel(Level)).Log(context.Background(), tc.level, "request failed", "err", tc.err)
+			assert.Equal(t, tc.expect, decode(t, &buf)["level"])
+		})
+	}
+
+	t.Run("raised records pass the level of the wrapped handler", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newLogger(&buf, slog.LevelError, RaiseLevel(Level))
+
+		logger.Debug("cache miss", "err", errs.B().Code(errs.NotFound).Err())
+		assert.Empty(t, buf.String())
+
+		logger.Debug("query failed", "err", errs.B().Code(errs.Internal).Err())
+		assert.Equal(t, "ERROR", decode(t, &buf)["level"])
+	})
+}
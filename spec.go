@@ -0,0 +1,305 @@
+package errs
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Severity is the severity of errors with a code, see CodeSpec.
+type Severity int
+
+const (
+	// SeverityInfo is used for expected outcomes, such as canceled operations.
+	SeverityInfo Severity = iota + 1
+	// SeverityWarning is used for errors caused by clients.
+	SeverityWarning
+	// SeverityError is used for errors caused by the server.
+	SeverityError
+	// SeverityCritical is used for errors that need immediate attention, such as data loss.
+	SeverityCritical
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unset"
+	}
+}
+
+// maxParents limits the number of parents followed when resolving a CodeSpec, so that cycles end.
+const maxParents = 8
+
+// CodeSpec describes a code registered with RegisterCodeSpec.
+//
+// Fields with zero values are unset. Unset fields of a code that specializes a Parent are taken from the parent,
+// unset fields of a built-in code are taken from its default mappings and the HTTP and gRPC codes of other codes
+// are mapped like Unknown. Remaining unset fields get default values:
+// the severity is derived from the HTTP and gRPC codes, the log level from the severity and the retryability from
+// the gRPC code.
+type CodeSpec struct {
+	// Name is the stable machine name of the code, e.g. "payment_declined". It is the string representation of
+	// the code, used on the wire by JSON, problems and gRPC statuses. It is derived from the description in
+	// snake_case when unset, but should be set as descriptions are expected to change: see WithName for RegisterCode.
+	// Registry.Validate reports the codes whose name is derived.
+	Name string
+	// Description is the human description of the code.
+	Description string
+	// HTTP is the HTTP status mapped to the code.
+	HTTP int
+	// GRPC is the gRPC code mapped to the code, codes.OK is unset.
+	GRPC codes.Code
+	// Severity is the severity of errors with the code.
+	Severity Severity
+	// LogLevel is the level errors with the code are logged with.
+	// It is slog.LevelError for SeverityError and SeverityCritical, and slog.LevelInfo otherwise by default.
+	LogLevel slog.Leveler
+	// Retryable reports whether errors with the code are retryable, see Code.Retryable.
+	Retryable *bool
+	// PublicMessage is the message sent to clients for errors with the code that have no message.
+	PublicMessage string
+	// DocURL is the URL of the documentation of the code.
+	DocURL string
+	// Parent is the code that this code specializes, Unknown is unset.
+	Parent Code
+}
+
+// WithName sets the stable machine name of the code, see CodeSpec.Name.
+// Codes registered without a name are named after their description, which changes their name on the wire
+// when the description changes.
+func WithName(name string) CodeOption {
+	return func(s *CodeSpec) {
+		s.Name = name
+	}
+}
+
+// RegisterCodeSpec registers a new code OR overrides an existing one with spec.
+// It is safe to call RegisterCodeSpec concurrently with other registrations and lookups.
+func RegisterCodeSpec(c Code, spec CodeSpec) {
+	defaultRegistry.RegisterSpec(c, spec)
+}
+
+// Spec returns the resolved CodeSpec of the code, see CodeSpec for how unset fields are resolved.
+func (c Code) Spec() CodeSpec {
+	return defaultRegistry.Spec(c)
+}
+
+// Name returns the stable machine name of the code.
+func (c Code) Name() string {
+	return defaultRegistry.resolved(c).Name
+}
+
+// Description returns the human description of the code.
+func (c Code) Description() string {
+	return defaultRegistry.resolved(c).Description
+}
+
+// Severity returns the severity of errors with the code.
+func (c Code) Severity() Severity {
+	return defaultRegistry.resolved(c).Severity
+}
+
+// LogLevel returns the level errors with the code are logged with.
+func (c Code) LogLevel() slog.Level {
+	return defaultRegistry.resolved(c).LogLevel.Level()
+}
+
+// PublicMessage returns the message sent to clients for errors with the code that have no message.
+func (c Code) PublicMessage() string {
+	return defaultRegistry.resolved(c).PublicMessage
+}
+
+// DocURL returns the URL of the documentation of the code, if any.
+func (c Code) DocURL() string {
+	return defaultRegistry.resolved(c).DocURL
+}
+
+// Parent returns the code that the code specializes, or Unknown.
+func (c Code) Parent() Code {
+	return defaultRegistry.resolved(c).Parent
+}
+
+// Is reports whether c is target or specializes it, directly or through its parents.
+func (c Code) Is(target Code) bool {
+	for i := 0; i <= maxParents; i++ {
+		if c == target {
+			return true
+		}
+		if c = c.Parent(); c == Unknown {
+			return false
+		}
+	}
+	return false
+}
+
+// RegisterSpec registers a new code OR overrides an existing one with spec.
+func (r *Registry) RegisterSpec(c Code, spec CodeSpec) {
+	r.update(func(m map[Code]CodeSpec) {
+		m[c] = spec
+	})
+}
+
+// Spec returns the resolved CodeSpec of the code, see CodeSpec for how unset fields are resolved.
+func (r *Registry) Spec(c Code) CodeSpec {
+	s := r.resolved(c)
+	// the returned spec must not share the resolved flag
+	retryable := *s.Retryable
+	s.Retryable = &retryable
+	return s
+}
+
+// resolved returns the resolved CodeSpec of the code. Its Retryable flag is shared and must not be modified.
+func (r *Registry) resolved(c Code) CodeSpec {
+	if s, ok := r.lookup(c); ok {
+		return s
+	}
+	if c.builtin() {
+		return builtinSpecs[c]
+	}
+	return builtinSpec(c)
+}
+
+// resolve returns the spec of c in specs with its unset fields resolved, see CodeSpec.
+func resolve(specs map[Code]CodeSpec, c Code, depth int) CodeSpec {
+	s, ok := specs[c]
+	if !ok {
+		return builtinSpec(c)
+	}
+
+	// the defaults derived from the mappings are derived again from the overridden mappings,
+	// codes that are not built-in are mapped like Unknown
+	base := builtinMappings(c)
+	if c.builtin() {
+		if s.Name == "" {
+			s.Name = base.Name
+		}
+		if s.Description == "" {
+			s.Description = base.Description
+		}
+	}
+	if s.Name == "" {
+		s.Name = snakeCase(s.Description)
+	}
+	if s.Name == "" {
+		s.Name = valueName(c)
+	}
+	if s.Parent != Unknown && s.Parent != c && depth < maxParents {
+		base = resolve(specs, s.Parent, depth+1)
+	}
+	if s.HTTP == 0 {
+		s.HTTP = base.HTTP
+	}
+	if s.GRPC == codes.OK {
+		s.GRPC = base.GRPC
+	}
+	if s.Severity == 0 {
+		s.Severity = base.Severity
+	}
+	if s.LogLevel == nil {
+		s.LogLevel = base.LogLevel
+	}
+	if s.Retryable == nil {
+		s.Retryable = base.Retryable
+	}
+	if s.PublicMessage == "" {
+		s.PublicMessage = base.PublicMessage
+	}
+	if s.DocURL == "" {
+		s.DocURL = base.DocURL
+	}
+	return withDefaults(s)
+}
+
+// builtinSpecs contains the default CodeSpec of the built-in codes.
+var builtinSpecs = func() (specs [CodeSize]CodeSpec) {
+	for c := range Code(CodeSize) {
+		specs[c] = builtinSpec(c)
+	}
+	return specs
+}()
+
+// builtinSpec returns the default CodeSpec of a code that is not registered.
+func builtinSpec(c Code) CodeSpec {
+	return withDefaults(builtinMappings(c))
+}
+
+// builtinMappings returns the default name, description, HTTP and gRPC codes of a code.
+// Codes that are not built-in are named after their value and mapped like Unknown.
+func builtinMappings(c Code) CodeSpec {
+	if !c.builtin() {
+		return CodeSpec{
+			Name:        valueName(c),
+			Description: valueName(c),
+			HTTP:        httpCodes[Unknown],
+			GRPC:        grpcCodes[Unknown],
+		}
+	}
+	return CodeSpec{
+		Name:        codeNames[c],
+		Description: strings.ReplaceAll(codeNames[c], "_", " "),
+		HTTP:        httpCodes[c],
+		GRPC:        grpcCodes[c],
+	}
+}
+
+// snakeCase returns s in lower case with the runs of characters other than letters and digits replaced with
+// underscores, e.g. "This is custom error" becomes "this_is_custom_error".
+func snakeCase(s string) string {
+	var buf strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(s) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			sep = buf.Len() > 0
+			continue
+		}
+		if sep {
+			buf.WriteByte('_')
+			sep = false
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// withDefaults sets the unset fields of s that have default values.
+func withDefaults(s CodeSpec) CodeSpec {
+	if s.Severity == 0 {
+		switch {
+		case s.GRPC == codes.DataLoss:
+			s.Severity = SeverityCritical
+		case s.HTTP >= http.StatusInternalServerError:
+			s.Severity = SeverityError
+		case s.GRPC == codes.Canceled:
+			s.Severity = SeverityInfo
+		case s.HTTP >= http.StatusBadRequest:
+			s.Severity = SeverityWarning
+		default:
+			s.Severity = SeverityInfo
+		}
+	}
+	if s.LogLevel == nil {
+		s.LogLevel = slog.LevelInfo
+		if s.Severity >= SeverityError {
+			s.LogLevel = slog.LevelError
+		}
+	}
+	retryable := retryableGRPC(s.GRPC)
+	if s.Retryable != nil {
+		retryable = *s.Retryable
+	}
+	// the returned spec must not share the registered flag
+	s.Retryable = &retryable
+	return s
+}
@@ -0,0 +1,160 @@
+package errs
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRegistry_Spec(t *testing.T) {
+	t.Run("built-in codes", func(t *testing.T) {
+		var r Registry
+		s := r.Spec(NotFound)
+		assert.Equal(t, "not_found", s.Name)
+		assert.Equal(t, "not found", s.Description)
+		assert.Equal(t, 404, s.HTTP)
+		assert.Equal(t, codes.NotFound, s.GRPC)
+		assert.Equal(t, SeverityWarning, s.Severity)
+		assert.Equal(t, slog.LevelInfo, s.LogLevel.Level())
+		assert.False(t, *s.Retryable)
+
+		assert.Equal(t, SeverityError, r.Spec(Internal).Severity)
+		assert.Equal(t, slog.LevelError, r.Spec(Internal).LogLevel.Level())
+		assert.Equal(t, SeverityCritical, r.Spec(DataLoss).Severity)
+		assert.Equal(t, SeverityInfo, r.Spec(Canceled).Severity)
+		assert.True(t, *r.Spec(Unavailable).Retryable)
+	})
+	t.Run("unset fields are inherited from the parent", func(t *testing.T) {
+		r := NewRegistry()
+		declined := Code(CodeSize + 30)
+		r.RegisterSpec(declined, CodeSpec{
+			Name:          "payment_declined",
+			Parent:        FailedPrecondition,
+			PublicMessage: "the payment was declined",
+		})
+		retried := Code(CodeSize + 31)
+		r.RegisterSpec(retried, CodeSpec{Name: "payment_retried", Parent: declined, GRPC: codes.Aborted})
+
+		s := r.Spec(declined)
+		assert.Equal(t, "payment_declined", s.Name)
+		assert.Empty(t, s.Description)
+		assert.Equal(t, 412, s.HTTP)
+		assert.Equal(t, codes.FailedPrecondition, s.GRPC)
+		assert.Equal(t, SeverityWarning, s.Severity)
+		assert.Equal(t, "payment_declined", r.String(declined))
+
+		s = r.Spec(retried)
+		assert.Equal(t, 412, s.HTTP)
+		assert.Equal(t, codes.Aborted, s.GRPC)
+		assert.Equal(t, "the payment was declined", s.PublicMessage)
+		assert.False(t, *s.Retryable, "retryability is inherited from the parent")
+	})
+	t.Run("overrides of built-in codes", func(t *testing.T) {
+		r := NewRegistry()
+		r.Register(NotFound, 503, codes.Unavailable, "")
+		s := r.Spec(NotFound)
+		assert.Equal(t, "not_found", s.Name)
+		assert.Equal(t, SeverityError, s.Severity, "derived from the new mappings")
+		assert.True(t, *s.Retryable)
+	})
+	t.Run("explicit values", func(t *testing.T) {
+		r := NewRegistry()
+		c := Code(CodeSize + 32)
+		r.RegisterSpec(c, CodeSpec{HTTP: 500, GRPC: codes.Internal, Severity: SeverityCritical, LogLevel: slog.LevelWarn})
+		s := r.Spec(c)
+		assert.Equal(t, SeverityCritical, s.Severity)
+		assert.Equal(t, slog.LevelWarn, s.LogLevel.Level())
+	})
+	t.Run("cycles", func(t *testing.T) {
+		r := NewRegistry()
+		a, b := Code(CodeSize+33), Code(CodeSize+34)
+		r.RegisterSpec(a, CodeSpec{Name: "a", Parent: b})
+		r.RegisterSpec(b, CodeSpec{Name: "b", Parent: a})
+		assert.NotPanics(t, func() { r.Spec(a) })
+	})
+	t.Run("returned specs do not share registered values", func(t *testing.T) {
+		r := NewRegistry()
+		retryable := true
+		c := Code(CodeSize + 35)
+		r.RegisterSpec(c, CodeSpec{Retryable: &retryable})
+		*r.Spec(c).Retryable = false
+		assert.True(t, r.Retryable(c))
+	})
+	t.Run("specs are resolved again when their parent changes", func(t *testing.T) {
+		r := NewRegistry()
+		parent, child := Code(CodeSize+36), Code(CodeSize+37)
+		r.RegisterSpec(child, CodeSpec{Name: "child", Parent: parent})
+		assert.Equal(t, 500, r.HTTP(child), "unregistered parents are mapped like Unknown")
+
+		r.RegisterSpec(parent, CodeSpec{Name: "parent", HTTP: 409, GRPC: codes.Aborted})
+		assert.Equal(t, 409, r.HTTP(child))
+		assert.True(t, r.Retryable(child))
+
+		r.Unregister(parent)
+		assert.Equal(t, 500, r.HTTP(child))
+	})
+	t.Run("lookups do not allocate", func(t *testing.T) {
+		r := NewRegistry()
+		c := Code(CodeSize + 38)
+		r.RegisterSpec(c, CodeSpec{Name: "custom", Parent: NotFound})
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _, _, _ = r.String(c), r.HTTP(c), r.GRPC(c), r.Retryable(c)
+			_, _ = r.resolved(c), r.resolved(NotFound)
+		})
+		assert.Zero(t, allocs)
+	})
+}
+
+func TestCode_Spec(t *testing.T) {
+	declined := Code(CodeSize + 40)
+	RegisterCodeSpec(declined, CodeSpec{
+		Name:          "payment_declined",
+		Description:   "payment declined",
+		Parent:        FailedPrecondition,
+		PublicMessage: "the payment was declined",
+		DocURL:        "https://example.com/payment_declined",
+	})
+	defer UnregisterCode(declined)
+
+	assert.Equal(t, "payment_declined", declined.Name())
+	assert.Equal(t, "payment declined", declined.Description())
+	assert.Equal(t, FailedPrecondition, declined.Parent())
+	assert.Equal(t, SeverityWarning, declined.Severity())
+	assert.Equal(t, slog.LevelInfo, declined.LogLevel())
+	assert.Equal(t, "https://example.com/payment_declined", declined.DocURL())
+	assert.True(t, declined.Is(FailedPrecondition))
+	assert.True(t, declined.Is(declined))
+	assert.False(t, declined.Is(NotFound))
+	assert.False(t, NotFound.Is(Unknown))
+	assert.Contains(t, Codes(), declined)
+	parsed, err := ParseCode("payment_declined")
+	require.NoError(t, err)
+	assert.Equal(t, declined, parsed)
+
+	e := B().Code(declined).Err().(*Error)
+	assert.Equal(t, "payment_declined: the payment was declined", e.SafeError())
+	assert.Equal(t, "payment_declined", e.Error(), "public messages are only sent to clients")
+	b, err := json.Marshal(e)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"op":"","message":["the payment was declined"],"code":"payment_declined"}`, string(b))
+}
+
+func TestCodes(t *testing.T) {
+	r := NewRegistry()
+	r.Register(NotFound, 410, codes.NotFound, "gone")
+	r.Register(CodeSize+2, 400, codes.InvalidArgument, "second")
+	r.Register(CodeSize+1, 400, codes.InvalidArgument, "first")
+
+	got := r.Codes()
+	assert.Len(t, got, CodeSize+2)
+	assert.Equal(t, []Code{CodeSize + 1, CodeSize + 2}, got[CodeSize:])
+}
+
+func TestMostSevere_Severity(t *testing.T) {
+	assert.Equal(t, DataLoss, MostSevere([]Code{NotFound, Internal, DataLoss}))
+	assert.Equal(t, NotFound, MostSevere([]Code{Canceled, NotFound}))
+}
@@ -1,9 +1,111 @@
 package status
 
-import "github.com/lordvidex/errs/v2"
+import (
+	"errors"
+	"slices"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lordvidex/errs/v2"
+	"github.com/lordvidex/errs/v2/internal/errspb"
+)
 
 // Err converts underlying *errs.Error to *status.Status recommended for grpc handlers
 func Err(err error) error {
-	instance := errs.Convert(err).(*errs.Error)
+	if m, ok := asMulti(err); ok {
+		return m.GRPCStatus().Err()
+	}
+	var instance *errs.Error
+	if !errors.As(err, &instance) {
+		instance = errs.Convert(err).(*errs.Error)
+	}
 	return instance.GRPCStatus().Err()
 }
+
+// asMulti returns the *errs.MultiError in the tree of err when no *errs.Error wraps it.
+// An *errs.Error wrapping a MultiError is converted with its own code and messages.
+func asMulti(err error) (*errs.MultiError, bool) {
+	var m *errs.MultiError
+	if !errors.As(err, &m) {
+		return nil, false
+	}
+	// the first *errs.Error is one of the errors of m when no *errs.Error wraps it
+	var e *errs.Error
+	return m, !errors.As(err, &e) || slices.Contains(m.Errors(), e)
+}
+
+// ToErrs converts a *status.Status received from a gRPC call back to an *errs.Error.
+// nil and OK statuses return nil.
+//
+// Statuses created by (*errs.Error).GRPCStatus are rebuilt with their code, operation and shown underlying errors.
+// Other statuses are converted with the errs.Code mapped to their gRPC code, their message and
+// the field violations of their errdetails.BadRequest.
+func ToErrs(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	var (
+		pb    *errspb.Error
+		info  *errdetails.ErrorInfo
+		br    *errdetails.BadRequest
+		retry *errdetails.RetryInfo
+	)
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errspb.Error:
+			pb = d
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			br = d
+		case *errdetails.RetryInfo:
+			retry = d
+		}
+	}
+
+	var (
+		b     *errs.Builder
+		cause error
+	)
+	if pb != nil {
+		b, cause = protoNode(pb), protoCauses(pb)
+	} else {
+		b = errs.B().Code(errs.CodeOf(st.Err())).Msg(st.Message())
+		if info != nil && info.GetDomain() == errs.ErrorDomain {
+			b.Code(parseCode(info.GetReason())).Op(info.GetMetadata()["op"])
+		}
+		for _, v := range br.GetFieldViolations() {
+			b.Field(v.GetField(), v.GetDescription())
+		}
+	}
+	if retry != nil {
+		b.RetryAfter(retry.GetRetryDelay().AsDuration())
+	}
+	return errs.Wrap(cause, b.Err())
+}
+
+// protoCauses returns the shown underlying errors described by pb.
+func protoCauses(pb *errspb.Error) error {
+	var err error
+	for i := len(pb.GetCauses()) - 1; i >= 0; i-- {
+		err = errs.Wrap(err, protoNode(pb.GetCauses()[i]).Show().Err())
+	}
+	return err
+}
+
+func protoNode(pb *errspb.Error) *errs.Builder {
+	b := errs.B().Code(parseCode(pb.GetCode())).Op(pb.GetOp()).Msg(pb.GetMessage()...)
+	for _, f := range pb.GetFields() {
+		b.FieldCode(f.GetField(), parseCode(f.GetCode()), f.GetDescription())
+	}
+	return b
+}
+
+// parseCode returns the errs.Code with the name s, or errs.Unknown.
+func parseCode(s string) errs.Code {
+	c, _ := errs.ParseCode(s)
+	return c
+}
@@ -0,0 +1,90 @@
+package status
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/lordvidex/errs/v2"
+)
+
+func TestToErrs(t *testing.T) {
+	t.Run("nil and OK statuses", func(t *testing.T) {
+		assert.NoError(t, ToErrs(nil))
+		assert.NoError(t, ToErrs(New(codes.OK, "")))
+	})
+	t.Run("round trip", func(t *testing.T) {
+		sentinel := errs.B().Code(errs.Aborted).Op("Postgres").Msg("connection failed").Show().Err()
+		err := errs.Wrap(
+			errs.Wrap(sentinel, errs.B().Code(errs.Internal).Op("Cache").Msg("cache miss").Err()),
+			errs.B().Code(errs.NotFound).Op("FetchItem").Msg("item not found").Err(),
+		)
+
+		// send the status over the wire
+		received := FromProto(Convert(Err(err)).Proto())
+
+		got := ToErrs(received)
+		assert.Equal(t, err.Error(), got.Error())
+		assert.ErrorIs(t, got, sentinel)
+
+		var e *errs.Error
+		require.ErrorAs(t, got, &e)
+		assert.Equal(t, errs.NotFound, e.Code)
+		assert.Equal(t, "FetchItem", e.Op)
+		assert.Equal(t, []string{"item not found"}, e.Msg)
+	})
+	t.Run("error info only", func(t *testing.T) {
+		st, err := New(codes.AlreadyExists, "user exists").WithDetails(&errdetails.ErrorInfo{
+			Reason:   "already_exists",
+			Domain:   errs.ErrorDomain,
+			Metadata: map[string]string{"op": "CreateUser"},
+		})
+		require.NoError(t, err)
+
+		expected := errs.B().Code(errs.AlreadyExists).Op("CreateUser").Msg("user exists").Err()
+		assert.True(t, errors.Is(ToErrs(st), expected))
+	})
+	t.Run("foreign status", func(t *testing.T) {
+		got := ToErrs(New(codes.PermissionDenied, "not allowed"))
+		assert.Equal(t, errs.B().Code(errs.Forbidden).Msg("not allowed").Err(), got)
+	})
+	t.Run("retry info", func(t *testing.T) {
+		err := errs.B().Code(errs.Unavailable).Msg("database is down").RetryAfter(3 * time.Second).Err()
+
+		got := ToErrs(FromProto(Convert(Err(err)).Proto()))
+		assert.Equal(t, err, got)
+		d, ok := errs.RetryAfter(got)
+		assert.True(t, ok)
+		assert.Equal(t, 3*time.Second, d)
+
+		st, stErr := New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)})
+		require.NoError(t, stErr)
+		d, ok = errs.RetryAfter(ToErrs(st))
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, d)
+	})
+}
+
+func TestToErrs_fields(t *testing.T) {
+	t.Run("errs status", func(t *testing.T) {
+		err := errs.B().Msg("invalid user").Field("email", "must be a valid address").FieldCode("name", errs.AlreadyExists, "is taken").Err()
+
+		got := ToErrs(FromProto(Convert(Err(err)).Proto()))
+		assert.Equal(t, err, got)
+	})
+	t.Run("bad request only", func(t *testing.T) {
+		st, err := New(codes.InvalidArgument, "invalid user").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "email", Description: "must be a valid address"}},
+		})
+		require.NoError(t, err)
+
+		expected := errs.B().Msg("invalid user").Field("email", "must be a valid address").Err()
+		assert.Equal(t, expected, ToErrs(st))
+	})
+}
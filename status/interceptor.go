@@ -0,0 +1,186 @@
+package status
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lordvidex/errs/v2"
+	"github.com/lordvidex/errs/v2/metrics"
+)
+
+// Option configures the interceptors of this package.
+type Option func(*options)
+
+type options struct {
+	hideInternal bool
+	logger       func(ctx context.Context, method string, err *errs.Error)
+	recorder     metrics.Recorder
+	registry     *errs.Registry
+}
+
+// HideInternal makes the server interceptors replace errors with the Internal and Unknown gRPC codes
+// with a status that only contains the code, so that internal messages do not reach clients.
+func HideInternal() Option {
+	return func(o *options) {
+		o.hideInternal = true
+	}
+}
+
+// WithLogger sets a function that is called by the server interceptors with every error returned by a handler,
+// before it is converted to a status. err.Stack() can be used to log the full error.
+func WithLogger(fn func(ctx context.Context, method string, err *errs.Error)) Option {
+	return func(o *options) {
+		o.logger = fn
+	}
+}
+
+// WithRecorder sets a metrics.Recorder that records every error returned by a handler to the server interceptors.
+// Errors without operation are recorded with the full method name as operation.
+func WithRecorder(r metrics.Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
+}
+
+// WithRegistry sets the errs.Registry that the server interceptors map the codes of errors to gRPC codes with,
+// instead of the default registry. Names of codes in the details of statuses come from the default registry,
+// see errs.Registry.
+func WithRegistry(reg *errs.Registry) Option {
+	return func(o *options) {
+		o.registry = reg
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{registry: errs.DefaultRegistry()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that converts errors returned by handlers
+// with errs.Convert and sends them as statuses created by (*errs.Error).GRPCStatus.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, o.serverErr(ctx, info.FullMethod, err)
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor that converts errors returned by handlers
+// with errs.Convert and sends them as statuses created by (*errs.Error).GRPCStatus.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return o.serverErr(ss.Context(), info.FullMethod, handler(srv, ss))
+	}
+}
+
+// serverErr converts err to a status error.
+// Status errors that were not created from an *errs.Error are returned as is.
+func (o *options) serverErr(ctx context.Context, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if o.recorder != nil {
+		obs := metrics.Observe(metrics.GRPC, err)
+		if obs.Op == "" {
+			obs.Op = method
+		}
+		o.recorder.Record(ctx, obs)
+	}
+
+	if m, ok := asMulti(err); ok {
+		return o.multiErr(ctx, method, m)
+	}
+
+	var e *errs.Error
+	if !errors.As(err, &e) {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		e = errs.Convert(err).(*errs.Error)
+	}
+
+	if o.logger != nil {
+		o.logger(ctx, method, e)
+	}
+
+	return o.status(e.Code, e.GRPCStatus()).Err()
+}
+
+// multiErr converts m to a status error, each contained error is logged separately.
+func (o *options) multiErr(ctx context.Context, method string, m *errs.MultiError) error {
+	if o.logger != nil {
+		for _, e := range m.Errors() {
+			o.logger(ctx, method, e)
+		}
+	}
+
+	return o.status(m.Code(), m.GRPCStatus()).Err()
+}
+
+// status returns st, the status of an error with the code c, with the gRPC code mapped to c by the registry.
+// The status only contains the code when internal errors are hidden.
+func (o *options) status(c errs.Code, st *status.Status) *status.Status {
+	grpcCode := o.registry.GRPC(c)
+	if o.hideInternal && (grpcCode == codes.Internal || grpcCode == codes.Unknown) {
+		st = errs.B().Code(c).Err().(*errs.Error).GRPCStatus()
+	}
+	if st.Code() != grpcCode {
+		pb := st.Proto()
+		pb.Code = int32(grpcCode)
+		st = status.FromProto(pb)
+	}
+	return st
+}
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor that converts status errors
+// returned by calls to *errs.Error with ToErrs.
+func UnaryClientInterceptor(_ ...Option) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return clientErr(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor returns a grpc.StreamClientInterceptor that converts status errors
+// returned by streams to *errs.Error with ToErrs.
+func StreamClientInterceptor(_ ...Option) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, clientErr(err)
+		}
+		return &clientStream{cs}, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	return clientErr(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	return clientErr(s.ClientStream.RecvMsg(m))
+}
+
+// clientErr converts status errors to *errs.Error. Other errors, such as io.EOF, are returned as is.
+func clientErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return ToErrs(st)
+}
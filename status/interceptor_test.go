@@ -0,0 +1,200 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/lordvidex/errs/v2"
+	"github.com/lordvidex/errs/v2/metrics"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.Users/Get"}
+	call := func(err error, opts ...Option) error {
+		_, got := UnaryServerInterceptor(opts...)(context.Background(), nil, info, func(context.Context, any) (any, error) {
+			return nil, err
+		})
+		return got
+	}
+
+	t.Run("nil error", func(t *testing.T) {
+		assert.NoError(t, call(nil))
+	})
+	t.Run("errs error", func(t *testing.T) {
+		err := errs.B().Code(errs.NotFound).Op("Users.Get").Msg("user not found").Err()
+		st := Convert(call(err))
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, err.Error(), st.Message())
+		assert.Equal(t, err, ToErrs(st))
+	})
+	t.Run("foreign error", func(t *testing.T) {
+		st := Convert(call(io.EOF))
+		assert.Equal(t, codes.Unknown, st.Code())
+		assert.Equal(t, "unknown", st.Message(), "internal messages are not sent")
+	})
+	t.Run("status error is kept", func(t *testing.T) {
+		err := Error(codes.Unimplemented, "not implemented")
+		assert.Equal(t, err, call(err))
+	})
+	t.Run("hide internal", func(t *testing.T) {
+		err := errs.B().Code(errs.Internal).Op("Postgres").Msg("connection refused").Err()
+		st := Convert(call(err, HideInternal()))
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "internal", st.Message())
+
+		notHidden := errs.B().Code(errs.NotFound).Msg("user not found").Err()
+		assert.Equal(t, notHidden.Error(), Convert(call(notHidden, HideInternal())).Message())
+	})
+	t.Run("logger", func(t *testing.T) {
+		var (
+			method string
+			logged *errs.Error
+		)
+		logger := WithLogger(func(_ context.Context, m string, err *errs.Error) {
+			method, logged = m, err
+		})
+		err := errs.WrapCode(io.EOF, errs.Internal, "reading failed")
+		_ = call(err, logger, HideInternal())
+		assert.Equal(t, info.FullMethod, method)
+		assert.Equal(t, err, logged)
+	})
+	t.Run("recorder", func(t *testing.T) {
+		rec := new(metrics.Memory)
+		_ = call(errs.B().Code(errs.Internal).Op("Users.Get").Err(), WithRecorder(rec))
+		_ = call(io.EOF, WithRecorder(rec))
+		_ = call(nil, WithRecorder(rec))
+		assert.Equal(t, []metrics.Observation{
+			{Transport: metrics.GRPC, Code: errs.Internal, Op: "Users.Get"},
+			{Transport: metrics.GRPC, Code: errs.Unknown, Op: info.FullMethod},
+		}, rec.Observations())
+	})
+	t.Run("registry", func(t *testing.T) {
+		reg := errs.NewRegistry()
+		reg.RegisterSpec(errs.NotFound, errs.CodeSpec{GRPC: codes.FailedPrecondition})
+		reg.RegisterSpec(errs.Unavailable, errs.CodeSpec{GRPC: codes.Internal})
+
+		err := errs.B().Code(errs.NotFound).Op("Users.Get").Msg("user not found").Err()
+		st := Convert(call(err, WithRegistry(reg)))
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		assert.Equal(t, err, ToErrs(st), "the details are kept")
+		assert.Equal(t, codes.NotFound, Convert(call(err)).Code(), "the default registry is not modified")
+
+		st = Convert(call(errs.B().Code(errs.Unavailable).Msg("database is down").Err(), WithRegistry(reg), HideInternal()))
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "unavailable", st.Message(), "codes mapped to internal are hidden")
+	})
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/users.Users/List"}
+	err := errs.B().Code(errs.Unavailable).Msg("try again").Err()
+	got := StreamServerInterceptor()(nil, &serverStream{}, info, func(any, grpc.ServerStream) error {
+		return err
+	})
+	assert.Equal(t, codes.Unavailable, Code(got))
+	assert.Equal(t, err, ToErrs(Convert(got)))
+}
+
+type serverStream struct {
+	grpc.ServerStream
+}
+
+func (*serverStream) Context() context.Context { return context.Background() }
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	call := func(err error) error {
+		return UnaryClientInterceptor()(context.Background(), "/users.Users/Get", nil, nil, nil,
+			func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				return err
+			})
+	}
+
+	assert.NoError(t, call(nil))
+
+	sent := errs.B().Code(errs.NotFound).Op("Users.Get").Msg("user not found").Err()
+	got := call(Err(sent))
+	assert.True(t, errors.Is(got, sent))
+
+	var e *errs.Error
+	require.ErrorAs(t, got, &e)
+	assert.Equal(t, errs.NotFound, e.Code)
+
+	assert.Equal(t, io.EOF, call(io.EOF))
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	sent := errs.B().Code(errs.Aborted).Msg("conflict").Err()
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &clientStreamStub{recv: Err(sent)}, nil
+	}
+
+	cs, err := StreamClientInterceptor()(context.Background(), nil, nil, "/users.Users/List", streamer)
+	require.NoError(t, err)
+	assert.True(t, errors.Is(cs.RecvMsg(nil), sent))
+	assert.Equal(t, io.EOF, cs.SendMsg(nil))
+
+	_, err = StreamClientInterceptor()(context.Background(), nil, nil, "/users.Users/List",
+		func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return nil, Err(sent)
+		})
+	assert.True(t, errors.Is(err, sent))
+}
+
+type clientStreamStub struct {
+	grpc.ClientStream
+	recv error
+}
+
+func (s *clientStreamStub) RecvMsg(any) error { return s.recv }
+func (s *clientStreamStub) SendMsg(any) error { return io.EOF }
+
+func TestUnaryServerInterceptor_multi(t *testing.T) {
+	err := errs.MultiB().
+		Add(errs.B().Code(errs.InvalidArgument).Op("name").Msg("name is required").Err()).
+		Add(errs.B().Code(errs.Internal).Op("Postgres").Msg("connection refused").Err()).
+		ErrOrNil()
+
+	var logged []*errs.Error
+	logger := WithLogger(func(_ context.Context, _ string, err *errs.Error) {
+		logged = append(logged, err)
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.Users/Create"}
+	_, got := UnaryServerInterceptor(logger, HideInternal())(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, err
+	})
+
+	st := Convert(got)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "internal", st.Message())
+	assert.Equal(t, err.(*errs.MultiError).Errors(), logged)
+	assert.Equal(t, codes.Internal, Code(Err(err)))
+}
+
+func TestUnaryServerInterceptor_wrappedMulti(t *testing.T) {
+	m := errs.MultiB().Add(errs.B().Code(errs.InvalidArgument).Op("name").Msg("name is required").Err()).ErrOrNil()
+	err := errs.WrapCode(m, errs.Unavailable, "validation service down")
+
+	var logged []*errs.Error
+	logger := WithLogger(func(_ context.Context, _ string, err *errs.Error) {
+		logged = append(logged, err)
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.Users/Create"}
+	_, got := UnaryServerInterceptor(logger)(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, err
+	})
+
+	st := Convert(got)
+	assert.Equal(t, codes.Unavailable, st.Code(), "the code of the wrapping error is kept")
+	assert.Equal(t, "unavailable: validation service down", st.Message())
+	assert.Equal(t, []*errs.Error{err.(*errs.Error)}, logged)
+	assert.Equal(t, codes.Unavailable, Code(Err(err)))
+	assert.Equal(t, codes.InvalidArgument, Code(Err(fmt.Errorf("creating user: %w", m))))
+}
@@ -1,5 +1,7 @@
 // Package status is a partial drop-in replacement of grpc/status that works with lordvidex/errs.
 // As a drop-in replacement, it simply calls *status.Status functions.
+//
+// It also provides server and client interceptors that convert errors between *errs.Error and *status.Status.
 package status
 
 import (
@@ -0,0 +1,49 @@
+package errs
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// maxFrames is the maximum number of frames captured for an error.
+const maxFrames = 32
+
+// tracing enables capturing of stack traces for all errors, see SetTracing.
+var tracing atomic.Bool
+
+// SetTracing enables or disables capturing of stack traces when errors are created with Builder.Err,
+// WrapB, WrapCode, WrapMsg and Wrap. Capturing is disabled by default.
+//
+// To capture the stack trace of a single error, use Builder.Trace instead.
+func SetTracing(enabled bool) {
+	tracing.Store(enabled)
+}
+
+// Frames returns the stack frames captured when the error was created.
+// It returns nil when no stack trace was captured.
+func (e *Error) Frames() []runtime.Frame {
+	if len(e.pcs) == 0 {
+		return nil
+	}
+	var result []runtime.Frame
+	frames := runtime.CallersFrames(e.pcs)
+	for {
+		frame, more := frames.Next()
+		result = append(result, frame)
+		if !more {
+			return result
+		}
+	}
+}
+
+// capture records the stack trace of the error if tracing is enabled or force is true.
+// skip is the number of frames to skip above the caller of capture.
+// Errors that already have a stack trace are not updated.
+func (e *Error) capture(skip int, force bool) {
+	if e.pcs != nil || !(force || tracing.Load()) {
+		return
+	}
+	var pcs [maxFrames]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	e.pcs = append([]uintptr(nil), pcs[:n]...)
+}
@@ -0,0 +1,67 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracing(t *testing.T) {
+	callerOf := func(err error) string {
+		frames := err.(*Error).Frames()
+		require.NotEmpty(t, frames)
+		return frames[0].Function
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.Nil(t, B().Code(NotFound).Err().(*Error).Frames())
+		assert.Nil(t, WrapCode(nil, NotFound).(*Error).Frames())
+		assert.Nil(t, WrapMsg(nil, "test").(*Error).Frames())
+	})
+	t.Run("per builder", func(t *testing.T) {
+		err := B().Code(NotFound).Trace().Err()
+		assert.Equal(t, "github.com/lordvidex/errs/v2.TestTracing.func3", callerOf(err))
+		assert.Nil(t, B().Code(NotFound).Err().(*Error).Frames())
+	})
+	t.Run("package level", func(t *testing.T) {
+		SetTracing(true)
+		defer SetTracing(false)
+
+		const caller = "github.com/lordvidex/errs/v2.TestTracing.func4"
+		assert.Equal(t, caller, callerOf(B().Err()))
+		assert.Equal(t, caller, callerOf(WrapB(nil).Err()))
+		assert.Equal(t, caller, callerOf(WrapCode(nil, Internal)))
+		assert.Equal(t, caller, callerOf(WrapMsg(nil, "test")))
+		assert.Equal(t, caller, callerOf(Wrap(&Error{Msg: []string{"child"}}, &Error{Msg: []string{"parent"}})))
+	})
+	t.Run("derived errors capture their own stack trace", func(t *testing.T) {
+		err := B().Trace().Err()
+		frames := err.(*Error).Frames()
+		derived := B(err).Trace().Err()
+		wrapped := Wrap(B().Err(), err)
+
+		require.NotEmpty(t, derived.(*Error).Frames())
+		assert.NotEqual(t, frames[0].Line, derived.(*Error).Frames()[0].Line)
+		assert.Nil(t, wrapped.(*Error).Frames(), "tracing is disabled")
+		assert.Equal(t, frames, err.(*Error).Frames(), "the original error is not modified")
+
+		SetTracing(true)
+		defer SetTracing(false)
+		wrapped = Wrap(B().Err(), err)
+		assert.NotEqual(t, frames[0].Line, wrapped.(*Error).Frames()[0].Line)
+	})
+}
+
+func TestError_Stack_frames(t *testing.T) {
+	err := WrapCode(B().Code(NotFound).Msg("item not found").Trace().Err(), Internal, "internal error")
+
+	stack := err.(*Error).Stack()
+	assert.True(t, strings.HasPrefix(stack, "internal: internal error\n\n"))
+	assert.Contains(t, stack, "\tnot_found: item not found\n\t\tgithub.com/lordvidex/errs/v2.TestError_Stack_frames\n\t\t\t")
+	assert.Contains(t, stack, "trace_test.go:")
+	assert.Equal(t, stack, fmt.Sprintf("%+v", err))
+	assert.Equal(t, err.Error(), fmt.Sprintf("%v", err))
+}